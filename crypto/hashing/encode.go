@@ -0,0 +1,72 @@
+package hashing
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/inovacc/utils/v2/encoding/encoder"
+)
+
+// ErrUnknownAlgorithm is returned when a digest format needs the algorithm
+// name or code and the Hasher was not built from one of this package's Algorithms.
+var ErrUnknownAlgorithm = errors.New("hashing: unknown algorithm")
+
+// Encode returns the digest in the given encoding, for example Base58 or URL-safe Base64
+func (d Digest) Encode(enc encoder.Encoding) (string, error) {
+	out, err := enc.Encode(d)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Sum returns the raw digest of data
+func (h *Hasher) Sum(data []byte) Digest {
+	nh := h.newHash()
+	nh.Write(data)
+	return nh.Sum(nil)
+}
+
+// HashEncoded returns the digest of data in the given encoding
+func (h *Hasher) HashEncoded(data []byte, enc encoder.Encoding) (string, error) {
+	return h.Sum(data).Encode(enc)
+}
+
+// Name returns the algorithm name, such as "sha256", or "" for a Func
+func (h *Hasher) Name() string {
+	if h.alg == nil {
+		return ""
+	}
+	return h.alg.name
+}
+
+// SRI returns a Subresource Integrity string such as "sha256-<base64>".
+// Only SHA256, SHA384 and SHA512 are valid SRI algorithms.
+func (h *Hasher) SRI(data []byte) (string, error) {
+	name := h.Name()
+	switch name {
+	case "sha256", "sha384", "sha512":
+	default:
+		return "", fmt.Errorf("%w: %q is not an SRI algorithm", ErrUnknownAlgorithm, name)
+	}
+
+	digest, err := h.HashEncoded(data, encoder.NewEncoding(encoder.Base64))
+	if err != nil {
+		return "", err
+	}
+	return name + "-" + digest, nil
+}
+
+// Multihash returns the digest of data prefixed with the varint multihash code
+// and digest length. Encode it with Base58 for the familiar "Qm..." form of SHA256.
+func (h *Hasher) Multihash(data []byte) ([]byte, error) {
+	if h.alg == nil || h.alg.code == 0 {
+		return nil, fmt.Errorf("%w: no multihash code for %q", ErrUnknownAlgorithm, h.Name())
+	}
+
+	digest := h.Sum(data)
+	out := binary.AppendUvarint(nil, h.alg.code)
+	out = binary.AppendUvarint(out, uint64(len(digest)))
+	return append(out, digest...), nil
+}
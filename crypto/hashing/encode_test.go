@@ -0,0 +1,134 @@
+package hashing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/inovacc/utils/v2/encoding/encoder"
+)
+
+func TestDigestEncode(t *testing.T) {
+	hasher := NewHasher(SHA256)
+	data := []byte("hello world")
+
+	raw := hasher.Sum(data)
+	if raw.Hex() != hasher.HashBytes(data) {
+		t.Error("Sum and HashBytes differ")
+		return
+	}
+
+	tests := []struct {
+		name string
+		enc  encoder.Encoding
+		want string
+	}{
+		{"Base64URL", encoder.NewEncoding(encoder.Base64, encoder.WithURLSafe(), encoder.WithNoPadding()), "uU0nuZNNPgilLlLX2n2r-sSE7-N6U4DukIj3rOLvzek"},
+		{"Hex", encoder.NewEncoding(encoder.Hex), "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
+	}
+
+	for _, tt := range tests {
+		got, err := hasher.HashEncoded(data, tt.enc)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+			return
+		}
+	}
+
+	for _, base := range []encoder.BaseType{encoder.Base58, encoder.Base62} {
+		enc := encoder.NewEncoding(base)
+		encoded, err := raw.Encode(enc)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		decoded, err := enc.DecodeStr(encoded)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !bytes.Equal([]byte(decoded), raw) {
+			t.Errorf("base %d: digest did not round trip", base)
+			return
+		}
+	}
+}
+
+func TestSRI(t *testing.T) {
+	// From the W3C Subresource Integrity specification examples.
+	data := []byte("alert('Hello, world.');")
+	want := "sha384-H8BRh8j48O9oYatfu5AZzq6A9RINhZO5H16dQZngK7T62em8MUt1FLm52t+eX6xO"
+
+	got, err := NewHasher(SHA384).SRI(data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got != want {
+		t.Errorf("SRI() = %s, want %s", got, want)
+		return
+	}
+
+	if _, err := NewHasher(SHA3_256).SRI(data); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("expected ErrUnknownAlgorithm, got %v", err)
+		return
+	}
+}
+
+func TestMultihash(t *testing.T) {
+	data := []byte("hello world")
+
+	mh, err := NewHasher(SHA256).Multihash(data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	if !bytes.Equal(mh[:2], []byte{0x12, 0x20}) || !bytes.Equal(mh[2:], sum[:]) {
+		t.Errorf("unexpected multihash %x", mh)
+		return
+	}
+
+	cid, err := encoder.NewEncoding(encoder.Base58).Encode(mh)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.HasPrefix(string(cid), "Qm") {
+		t.Errorf("expected base58 multihash to start with Qm, got %s", cid)
+		return
+	}
+
+	blake, err := NewHasher(BLAKE2b_256).Multihash(data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(blake[:3], []byte{0xa0, 0xe4, 0x02}) || blake[3] != 32 {
+		t.Errorf("unexpected blake2b-256 multihash prefix %x", blake[:4])
+		return
+	}
+
+	if _, err := NewHasher(CRC32C).Multihash(data); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("expected ErrUnknownAlgorithm, got %v", err)
+		return
+	}
+}
+
+func TestName(t *testing.T) {
+	if name := NewHasher(SHA512_256).Name(); name != "sha512-256" {
+		t.Errorf("Name() = %q, want sha512-256", name)
+		return
+	}
+	if name := NewHasher(Func(sha256.New)).Name(); name != "" {
+		t.Errorf("Name() = %q, want empty for a Func", name)
+		return
+	}
+}
@@ -0,0 +1,76 @@
+package hashing
+
+import (
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+
+	"github.com/cespare/xxhash/v2"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
+)
+
+// Algorithm is a hash function defined by this package. It knows its name and
+// multihash code, which SRI and Multihash need, and whether it is
+// cryptographically secure.
+type Algorithm struct {
+	name    string
+	code    uint64 // multihash code, 0 if none
+	secure  bool
+	newHash func() hash.Hash
+}
+
+// New returns a new hash.Hash computing the algorithm
+func (a *Algorithm) New() hash.Hash {
+	return a.newHash()
+}
+
+// Name returns the algorithm name, such as "sha256"
+func (a *Algorithm) Name() string {
+	return a.name
+}
+
+// SHA-3, SHAKE, BLAKE2 and BLAKE3 hash functions. SHAKE128 and SHAKE256 produce
+// fixed 32 and 64 byte digests, the lengths at which they reach full strength.
+var (
+	SHA3_256    = &Algorithm{"sha3-256", 0x16, true, sha3.New256}
+	SHA3_512    = &Algorithm{"sha3-512", 0x14, true, sha3.New512}
+	SHAKE128    = &Algorithm{"shake128", 0x18, true, func() hash.Hash { return sha3.NewShake128() }}
+	SHAKE256    = &Algorithm{"shake256", 0x19, true, func() hash.Hash { return sha3.NewShake256() }}
+	BLAKE2b_256 = &Algorithm{"blake2b-256", 0xb220, true, func() hash.Hash { return mustHash(blake2b.New256(nil)) }}
+	BLAKE2b_512 = &Algorithm{"blake2b-512", 0xb240, true, func() hash.Hash { return mustHash(blake2b.New512(nil)) }}
+	BLAKE2s_256 = &Algorithm{"blake2s-256", 0xb260, true, func() hash.Hash { return mustHash(blake2s.New256(nil)) }}
+	BLAKE3      = &Algorithm{"blake3", 0x1e, true, func() hash.Hash { return blake3.New(32, nil) }}
+)
+
+// Fast non-cryptographic hash functions, suitable for checksums and hash
+// tables but not for integrity against an attacker
+var (
+	XXHash64 = &Algorithm{"xxhash64", 0, false, func() hash.Hash { return xxhash.New() }}
+	CRC32C   = &Algorithm{"crc32c", 0, false, func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) }}
+	FNV1a32  = &Algorithm{"fnv1a-32", 0, false, func() hash.Hash { return fnv.New32a() }}
+	FNV1a64  = &Algorithm{"fnv1a-64", 0, false, func() hash.Hash { return fnv.New64a() }}
+)
+
+// mustHash unwraps constructors that only fail for an invalid key
+func mustHash(h hash.Hash, err error) hash.Hash {
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// IsCryptographic reports whether hf is one of this package's cryptographically
+// secure algorithms. It returns false for the checksums XXHash64, CRC32C and
+// FNV-1a and for any Func, such as Func(md5.New).
+func IsCryptographic(hf HashFunction) bool {
+	a, ok := hf.(*Algorithm)
+	return ok && a.secure
+}
+
+// IsCryptographic reports whether the Hasher's function is cryptographically secure
+func (h *Hasher) IsCryptographic() bool {
+	return h.alg != nil && h.alg.secure
+}
@@ -0,0 +1,73 @@
+package hashing
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"strings"
+	"testing"
+)
+
+func TestHashFamilies(t *testing.T) {
+	tests := []struct {
+		name          string
+		hashFunc      HashFunction
+		input         string
+		want          string
+		cryptographic bool
+	}{
+		{"SHA3-256", SHA3_256, "abc", "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532", true},
+		{"SHAKE128", SHAKE128, "", "7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26", true},
+		{"BLAKE2b-512", BLAKE2b_512, "abc", "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923", true},
+		{"BLAKE2s-256", BLAKE2s_256, "abc", "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982", true},
+		{"BLAKE3", BLAKE3, "abc", "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", true},
+		{"MD5", Func(md5.New), "abc", "900150983cd24fb0d6963f7d28e17f72", false},
+		{"SHA-1", Func(sha1.New), "abc", "a9993e364706816aba3e25717850c26c9cd0d89d", false},
+		{"XXHash64-empty", XXHash64, "", "ef46db3751d8e999", false},
+		{"XXHash64", XXHash64, "abc", "44bc2cf5ad770999", false},
+		{"CRC32C", CRC32C, "123456789", "e3069283", false},
+		{"FNV-1a-32", FNV1a32, "", "811c9dc5", false},
+		{"FNV-1a-64", FNV1a64, "a", "af63dc4c8601ec8c", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher := NewHasher(tt.hashFunc)
+			if got := hasher.HashString(tt.input); got != tt.want {
+				t.Errorf("HashString(%q) = %s, want %s", tt.input, got, tt.want)
+				return
+			}
+
+			if hasher.IsCryptographic() != tt.cryptographic {
+				t.Errorf("IsCryptographic() = %v, want %v", hasher.IsCryptographic(), tt.cryptographic)
+				return
+			}
+		})
+	}
+
+	if !IsCryptographic(SHA256) {
+		t.Error("expected SHA256 to be cryptographic")
+		return
+	}
+	if IsCryptographic(Func(sha256.New)) {
+		t.Error("expected a Func to be reported as not cryptographic")
+		return
+	}
+}
+
+func TestXXHash64Streaming(t *testing.T) {
+	data := []byte(strings.Repeat("xxhash streaming input ", 20))
+	hasher := NewHasher(XXHash64)
+	want := hasher.HashBytes(data)
+
+	for _, size := range []int{1, 7, 31, 32, 33} {
+		h := hasher.Reset()
+		for i := 0; i < len(data); i += size {
+			h.Write(data[i:min(len(data), i+size)])
+		}
+		if got := Digest(h.Sum(nil)).Hex(); got != want {
+			t.Errorf("chunk size %d: got %s, want %s", size, got, want)
+			return
+		}
+	}
+}
@@ -1,35 +1,49 @@
 package hashing
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/hex"
 	"hash"
 )
 
-// HashFunction represents a function that creates a new hash.Hash
-type HashFunction func() hash.Hash
+// HashFunction creates the hash.Hash instances a Hasher uses. The package's
+// algorithms, such as SHA256, implement it; wrap other constructors with Func.
+type HashFunction interface {
+	New() hash.Hash
+}
+
+// Func adapts a constructor such as md5.New to a HashFunction
+type Func func() hash.Hash
+
+// New calls f
+func (f Func) New() hash.Hash {
+	return f()
+}
 
 // Common hash functions
 var (
-	SHA224     HashFunction = sha256.New224
-	SHA256     HashFunction = sha256.New
-	SHA384     HashFunction = sha512.New384
-	SHA512     HashFunction = sha512.New
-	SHA512_224 HashFunction = sha512.New512_224
-	SHA512_256 HashFunction = sha512.New512_256
+	SHA224     = &Algorithm{"sha224", 0x1013, true, sha256.New224}
+	SHA256     = &Algorithm{"sha256", 0x12, true, sha256.New}
+	SHA384     = &Algorithm{"sha384", 0x20, true, sha512.New384}
+	SHA512     = &Algorithm{"sha512", 0x13, true, sha512.New}
+	SHA512_224 = &Algorithm{"sha512-224", 0x1014, true, sha512.New512_224}
+	SHA512_256 = &Algorithm{"sha512-256", 0x1015, true, sha512.New512_256}
 )
 
 // Hasher provides a unified interface for hashing operations
 type Hasher struct {
-	newHash HashFunction
+	newHash func() hash.Hash
+	alg     *Algorithm
 }
 
 // NewHasher creates a new Hasher with the specified hash function
 func NewHasher(hf HashFunction) *Hasher {
+	alg, _ := hf.(*Algorithm)
 	return &Hasher{
-		newHash: hf,
+		newHash: hf.New,
+		alg:     alg,
 	}
 }
 
@@ -60,12 +74,47 @@ func (h *Hasher) Reset() hash.Hash {
 	return h.newHash()
 }
 
-// CompareString compares two hashes and returns true if they are equal
+// Verify hashes data and reports whether the digest matches expectedHex.
+// The comparison is constant-time and expectedHex is case-insensitive; a
+// malformed or wrong-length hex string never matches.
+func (h *Hasher) Verify(data []byte, expectedHex string) bool {
+	expected, err := hex.DecodeString(expectedHex)
+	if err != nil {
+		return false
+	}
+	nh := h.newHash()
+	nh.Write(data)
+	return subtle.ConstantTimeCompare(nh.Sum(nil), expected) == 1
+}
+
+// VerifyString is Verify for string input
+func (h *Hasher) VerifyString(data, expectedHex string) bool {
+	return h.Verify([]byte(data), expectedHex)
+}
+
+// CompareString compares two already-encoded digests byte for byte in constant
+// time. It does not hash its arguments; use VerifyString to check plaintext and
+// CompareHex for hex digests that may differ in case.
 func (h *Hasher) CompareString(a, b string) bool {
 	return h.CompareBytes([]byte(a), []byte(b))
 }
 
-// CompareBytes compares two hashes and returns true if they are equal
+// CompareHex decodes two hex digests and compares them in constant time, so
+// "AB…" and "ab…" match. Malformed hex never matches.
+func (h *Hasher) CompareHex(a, b string) bool {
+	da, err := hex.DecodeString(a)
+	if err != nil {
+		return false
+	}
+	db, err := hex.DecodeString(b)
+	if err != nil {
+		return false
+	}
+	return h.CompareBytes(da, db)
+}
+
+// CompareBytes compares two digests in constant time.
+// It does not hash its arguments; use Verify to check plaintext.
 func (h *Hasher) CompareBytes(a, b []byte) bool {
-	return bytes.Equal(a, b)
+	return subtle.ConstantTimeCompare(a, b) == 1
 }
@@ -1,6 +1,7 @@
 package hashing
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -54,3 +55,53 @@ func TestHasher(t *testing.T) {
 		})
 	}
 }
+
+func TestVerify(t *testing.T) {
+	hasher := NewHasher(SHA256)
+	digest := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	if !hasher.VerifyString("test", digest) {
+		t.Error("expected digest of \"test\" to verify")
+		return
+	}
+
+	if !hasher.Verify([]byte("test"), strings.ToUpper(digest)) {
+		t.Error("expected uppercase hex digest to verify")
+		return
+	}
+
+	if hasher.VerifyString("tset", digest) {
+		t.Error("expected different input not to verify")
+		return
+	}
+
+	if hasher.VerifyString("test", digest[:10]) || hasher.VerifyString("test", "zz") {
+		t.Error("expected truncated or malformed digest not to verify")
+		return
+	}
+}
+
+func TestCompare(t *testing.T) {
+	hasher := NewHasher(SHA256)
+	a := hasher.HashString("a")
+
+	if !hasher.CompareString(a, hasher.HashString("a")) {
+		t.Error("expected equal digests to compare equal")
+		return
+	}
+
+	if hasher.CompareString(a, hasher.HashString("b")) || hasher.CompareString(a, a[:8]) {
+		t.Error("expected different digests to compare unequal")
+		return
+	}
+
+	if !hasher.CompareHex(a, strings.ToUpper(a)) {
+		t.Error("expected CompareHex to ignore hex case")
+		return
+	}
+
+	if hasher.CompareHex(a, hasher.HashString("b")) || hasher.CompareHex(a, "zz") {
+		t.Error("expected CompareHex to reject different or malformed digests")
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package hashing
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"hash"
+)
+
+// ErrNotCryptographic is returned when HMAC or HKDF is requested over a
+// function that is not one of this package's cryptographically secure
+// algorithms, such as CRC32C or a Func.
+var ErrNotCryptographic = errors.New("hashing: not a cryptographic hash function")
+
+// NewHMAC returns a keyed hash.Hash for streaming HMAC computation
+func (h *Hasher) NewHMAC(key []byte) (hash.Hash, error) {
+	if !h.IsCryptographic() {
+		return nil, ErrNotCryptographic
+	}
+	return hmac.New(h.newHash, key), nil
+}
+
+// Sign returns the HMAC of data under key
+func (h *Hasher) Sign(key, data []byte) (Digest, error) {
+	mac, err := h.NewHMAC(key)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// VerifySignature recomputes the HMAC of data under key and compares it with
+// the hex-encoded signature in constant time
+func (h *Hasher) VerifySignature(key, data []byte, signatureHex string) bool {
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return false
+	}
+	return h.VerifyMAC(key, data, signature)
+}
+
+// VerifyMAC recomputes the HMAC of data under key and compares it with the raw
+// mac in constant time. It never verifies for a non-cryptographic function.
+func (h *Hasher) VerifyMAC(key, data, mac []byte) bool {
+	expected, err := h.Sign(key, data)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, mac)
+}
+
+// DeriveKey derives a key of length bytes from secret using HKDF (RFC 5869).
+// Salt may be nil; info binds the key to its purpose, such as a tenant ID.
+func (h *Hasher) DeriveKey(secret, salt []byte, info string, length int) ([]byte, error) {
+	if !h.IsCryptographic() {
+		return nil, ErrNotCryptographic
+	}
+	return hkdf.Key(h.newHash, secret, salt, info, length)
+}
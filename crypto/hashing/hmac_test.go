@@ -0,0 +1,97 @@
+package hashing
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2.
+	key := []byte("Jefe")
+	data := []byte("what do ya want for nothing?")
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+
+	hasher := NewHasher(SHA256)
+	sig, err := hasher.Sign(key, data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got := sig.Hex(); got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+		return
+	}
+
+	if !hasher.VerifySignature(key, data, want) {
+		t.Error("expected signature to verify")
+		return
+	}
+
+	if hasher.VerifySignature([]byte("other"), data, want) {
+		t.Error("expected signature with wrong key not to verify")
+		return
+	}
+
+	if hasher.VerifySignature(key, data, "not hex") {
+		t.Error("expected malformed signature not to verify")
+		return
+	}
+
+	mac, err := hasher.NewHMAC(key)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mac.Write(data[:10])
+	mac.Write(data[10:])
+	if !hasher.VerifyMAC(key, data, mac.Sum(nil)) {
+		t.Error("expected streamed HMAC to verify")
+		return
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// RFC 5869 test case 1.
+	ikm, _ := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	want := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+
+	key, err := NewHasher(SHA256).DeriveKey(ikm, salt, string(info), 42)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got := hex.EncodeToString(key); got != want {
+		t.Errorf("DeriveKey() = %s, want %s", got, want)
+		return
+	}
+}
+
+func TestHMACRejectsNonCryptographic(t *testing.T) {
+	key := []byte("key")
+	data := []byte("data")
+
+	for _, hf := range []HashFunction{CRC32C, XXHash64, FNV1a64, Func(md5.New)} {
+		hasher := NewHasher(hf)
+		if _, err := hasher.Sign(key, data); !errors.Is(err, ErrNotCryptographic) {
+			t.Errorf("Sign: expected ErrNotCryptographic, got %v", err)
+			return
+		}
+		if _, err := hasher.NewHMAC(key); !errors.Is(err, ErrNotCryptographic) {
+			t.Errorf("NewHMAC: expected ErrNotCryptographic, got %v", err)
+			return
+		}
+		if hasher.VerifyMAC(key, data, nil) {
+			t.Error("expected VerifyMAC to fail")
+			return
+		}
+		if _, err := hasher.DeriveKey(key, nil, "info", 16); !errors.Is(err, ErrNotCryptographic) {
+			t.Errorf("DeriveKey: expected ErrNotCryptographic, got %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package hashing
+
+import (
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/spf13/afero"
+)
+
+// Digest is a raw hash output
+type Digest []byte
+
+// Hex returns the lowercase hexadecimal form of the digest
+func (d Digest) Hex() string {
+	return hex.EncodeToString(d)
+}
+
+// String returns the digest as hex
+func (d Digest) String() string {
+	return d.Hex()
+}
+
+// HashReader hashes everything read from r until EOF
+func (h *Hasher) HashReader(r io.Reader) (Digest, error) {
+	nh := h.newHash()
+	if _, err := io.Copy(nh, r); err != nil {
+		return nil, err
+	}
+	return nh.Sum(nil), nil
+}
+
+// HashFile hashes the file at path on fs without loading it into memory
+func (h *Hasher) HashFile(fs afero.Fs, path string) (Digest, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func(f afero.File) {
+		_ = f.Close()
+	}(f)
+
+	return h.HashReader(f)
+}
+
+// HashingWriter writes through to an underlying writer while hashing
+// everything that was successfully written
+type HashingWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	n    int64
+}
+
+// NewWriter returns a HashingWriter that tees writes into w. A nil w only hashes.
+func (h *Hasher) NewWriter(w io.Writer) *HashingWriter {
+	if w == nil {
+		w = io.Discard
+	}
+	return &HashingWriter{w: w, hash: h.newHash()}
+}
+
+// Write writes p to the underlying writer and hashes the bytes it accepted
+func (hw *HashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.hash.Write(p[:n])
+	hw.n += int64(n)
+	return n, err
+}
+
+// Sum returns the digest of everything written so far
+func (hw *HashingWriter) Sum() Digest {
+	return hw.hash.Sum(nil)
+}
+
+// Written returns the number of bytes written so far
+func (hw *HashingWriter) Written() int64 {
+	return hw.n
+}
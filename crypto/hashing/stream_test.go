@@ -0,0 +1,84 @@
+package hashing
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestHashReader(t *testing.T) {
+	hasher := NewHasher(SHA256)
+	data := strings.Repeat("stream me ", 10000)
+
+	digest, err := hasher.HashReader(strings.NewReader(data))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if digest.Hex() != hasher.HashString(data) {
+		t.Errorf("HashReader and HashString differ:\nReader: %s\nString: %s", digest.Hex(), hasher.HashString(data))
+		return
+	}
+
+	if len(digest) != hasher.GetSize() {
+		t.Errorf("raw digest has %d bytes, want %d", len(digest), hasher.GetSize())
+		return
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	data := []byte("artifact contents")
+	if err := afero.WriteFile(fs, "/artifacts/build.bin", data, 0o644); err != nil {
+		t.Error(err)
+		return
+	}
+
+	hasher := NewHasher(SHA512)
+	digest, err := hasher.HashFile(fs, "/artifacts/build.bin")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if digest.String() != hasher.HashBytes(data) {
+		t.Errorf("HashFile and HashBytes differ")
+		return
+	}
+
+	if _, err := hasher.HashFile(fs, "/missing"); err == nil {
+		t.Error("expected error for missing file")
+		return
+	}
+}
+
+func TestHashingWriter(t *testing.T) {
+	hasher := NewHasher(SHA256)
+	data := []byte(strings.Repeat("upload ", 1000))
+
+	var dst bytes.Buffer
+	w := hasher.NewWriter(&dst)
+	if _, err := io.Copy(w, bytes.NewReader(data)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !bytes.Equal(dst.Bytes(), data) {
+		t.Error("data was not written through")
+		return
+	}
+
+	if w.Written() != int64(len(data)) {
+		t.Errorf("Written() = %d, want %d", w.Written(), len(data))
+		return
+	}
+
+	if !hasher.Verify(data, w.Sum().Hex()) {
+		t.Error("HashingWriter digest does not match")
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package codec
+
+import (
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborCodec implements CBOR (RFC 8949) with github.com/fxamacker/cbor. Encoding
+// uses the Core Deterministic options (sorted map keys, smallest integer and
+// length forms); times are written as RFC 3339 strings and TextMarshaler types
+// as their text. Decoding into an interface yields map[string]any for maps.
+type cborCodec struct{}
+
+var (
+	cborEnc = newCBOREncMode()
+	cborDec = newCBORDecMode()
+)
+
+func (cborCodec) Marshal(v any) ([]byte, error)      { return cborEnc.Marshal(v) }
+func (cborCodec) Unmarshal(data []byte, v any) error { return cborDec.Unmarshal(data, v) }
+
+func newCBOREncMode() cbor.EncMode {
+	opts := cbor.CoreDetEncOptions()
+	opts.Time = cbor.TimeRFC3339Nano
+	opts.TextMarshaler = cbor.TextMarshalerTextString
+	mode, err := opts.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
+
+func newCBORDecMode() cbor.DecMode {
+	mode, err := cbor.DecOptions{
+		DefaultMapType:  reflect.TypeFor[map[string]any](),
+		TextUnmarshaler: cbor.TextUnmarshalerTextString,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
@@ -0,0 +1,84 @@
+// Package codec provides interchangeable serialization formats behind a single
+// Marshal/Unmarshal interface, so the format used for stored values can be
+// chosen by configuration.
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Format names a serialization format.
+type Format string
+
+const (
+	FormatJSON          Format = "json"           // encoding/json
+	FormatCanonicalJSON Format = "canonical-json" // JSON with sorted keys and no insignificant whitespace
+	FormatGob           Format = "gob"            // encoding/gob
+	FormatMsgPack       Format = "msgpack"        // MessagePack
+	FormatCBOR          Format = "cbor"           // CBOR (RFC 8949)
+)
+
+var builtinFormats = []Format{FormatJSON, FormatCanonicalJSON, FormatGob, FormatMsgPack, FormatCBOR}
+
+// ErrUnknownFormat is returned by New for a format that is neither built in
+// nor registered.
+var ErrUnknownFormat = errors.New("codec: unknown format")
+
+// Codec serializes Go values to bytes and back.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[Format]Codec)
+)
+
+// New returns the codec for the given format.
+func New(f Format) (Codec, error) {
+	switch f {
+	case FormatJSON:
+		return jsonCodec{}, nil
+	case FormatCanonicalJSON:
+		return canonicalJSONCodec{}, nil
+	case FormatGob:
+		return gobCodec{}, nil
+	case FormatMsgPack:
+		return msgpackCodec{}, nil
+	case FormatCBOR:
+		return cborCodec{}, nil
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if c, ok := registry[f]; ok {
+		return c, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, f)
+}
+
+// Register makes a codec available to New under the given format name.
+// It panics if the name is empty, the codec is nil, or the name is already
+// taken by a built-in or previously registered codec.
+func Register(f Format, c Codec) {
+	if f == "" {
+		panic("codec: Register format is empty")
+	}
+	if c == nil {
+		panic("codec: Register codec is nil")
+	}
+	if slices.Contains(builtinFormats, f) {
+		panic(fmt.Sprintf("codec: Register called for built-in format %q", f))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[f]; dup {
+		panic(fmt.Sprintf("codec: Register called twice for format %q", f))
+	}
+	registry[f] = c
+}
@@ -0,0 +1,390 @@
+package codec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type record struct {
+	Name    string            `json:"name"`
+	Age     int               `json:"age"`
+	Score   float64           `json:"score"`
+	Delta   int8              `json:"delta"`
+	Tags    []string          `json:"tags"`
+	Attrs   map[string]uint16 `json:"attrs"`
+	Blob    []byte            `json:"blob"`
+	Parent  *record           `json:"parent,omitempty"`
+	Created time.Time         `json:"created"`
+	Skipped string            `json:"-"`
+}
+
+func sampleRecord() record {
+	return record{
+		Name:    "Ana",
+		Age:     24,
+		Score:   3.1416,
+		Delta:   -100,
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]uint16{"x": 1, "y": 65535},
+		Blob:    []byte{0, 1, 2, 255},
+		Parent:  &record{Name: "Root", Age: -1, Created: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Created: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range builtinFormats {
+		t.Run(string(format), func(t *testing.T) {
+			c, err := New(format)
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+
+			in := sampleRecord()
+			data, err := c.Marshal(in)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var out record
+			if err := c.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			// msgpack decodes timestamps in the local zone.
+			out.Created = out.Created.UTC()
+			if out.Parent != nil {
+				out.Parent.Created = out.Parent.Created.UTC()
+			}
+			if !reflect.DeepEqual(in, out) {
+				t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", out, in)
+			}
+		})
+	}
+}
+
+func TestMsgPackVectors(t *testing.T) {
+	c, _ := New(FormatMsgPack)
+
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{map[string]any{"a": 1, "b": []any{true, nil}}, "82a16101a16292c3c0"},
+		{-33, "d0df"},
+		{uint32(70000), "ce00011170"},
+		{"", "a0"},
+		{[]byte{1}, "c40101"},
+		{1.5, "cb3ff8000000000000"},
+	}
+
+	for _, tt := range tests {
+		data, err := c.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("Marshal(%v) failed: %v", tt.in, err)
+		}
+		if got := hex.EncodeToString(data); got != tt.want {
+			t.Fatalf("Marshal(%v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCBORVectors(t *testing.T) {
+	c, _ := New(FormatCBOR)
+
+	// Examples from RFC 8949 Appendix A.
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{1000000, "1a000f4240"},
+		{-1000, "3903e7"},
+		{[]any{"a", map[string]string{"b": "c"}}, "826161a161626163"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{false, "f4"},
+		{nil, "f6"},
+	}
+
+	for _, tt := range tests {
+		data, err := c.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("Marshal(%v) failed: %v", tt.in, err)
+		}
+		if got := hex.EncodeToString(data); got != tt.want {
+			t.Fatalf("Marshal(%v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	var f float64
+	half, _ := hex.DecodeString("f93e00")
+	if err := c.Unmarshal(half, &f); err != nil || f != 1.5 {
+		t.Fatalf("Unmarshal half float = %v, %v; want 1.5", f, err)
+	}
+
+	var s string
+	tagged, _ := hex.DecodeString("c074323031332d30332d32315432303a30343a30305a")
+	if err := c.Unmarshal(tagged, &s); err != nil || s != "2013-03-21T20:04:00Z" {
+		t.Fatalf("Unmarshal tagged string = %q, %v", s, err)
+	}
+}
+
+func TestUnmarshalInterface(t *testing.T) {
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		c, _ := New(format)
+
+		data, err := c.Marshal(map[string]any{"n": -5, "list": []any{"x", 2.5}})
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", format, err)
+		}
+
+		var out any
+		if err := c.Unmarshal(data, &out); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", format, err)
+		}
+
+		want := map[string]any{"n": int64(-5), "list": []any{"x", 2.5}}
+		if !reflect.DeepEqual(out, want) {
+			t.Fatalf("%s: got %#v, want %#v", format, out, want)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		c, _ := New(format)
+
+		data, _ := c.Marshal([]string{"truncated"})
+		var out []string
+		if err := c.Unmarshal(data[:len(data)-2], &out); err == nil {
+			t.Fatalf("%s: expected error for truncated input", format)
+		}
+
+		big, _ := c.Marshal(300)
+		var small uint8
+		// msgpack truncates out-of-range integers like a Go conversion.
+		if err := c.Unmarshal(big, &small); format == FormatCBOR && err == nil {
+			t.Fatalf("%s: expected overflow error", format)
+		}
+
+		if err := c.Unmarshal(big, small); err == nil {
+			t.Fatalf("%s: expected error for non-pointer target", format)
+		}
+
+		if _, err := c.Marshal(make(chan int)); err == nil {
+			t.Fatalf("%s: expected error for unsupported type", format)
+		}
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	c, _ := New(FormatCanonicalJSON)
+
+	v := struct {
+		Zeta  int               `json:"zeta"`
+		Alpha string            `json:"alpha"`
+		Inner map[string]string `json:"inner"`
+	}{1, "<a&b>", map[string]string{"b": "2", "a": "1"}}
+
+	data, err := c.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	want := `{"alpha":"<a&b>","inner":{"a":"1","b":"2"},"zeta":1}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+}
+
+func TestMapOrderIsDeterministic(t *testing.T) {
+	ints := map[string]int{}
+	values := map[string]any{}
+	for _, k := range []string{"q", "w", "e", "r", "t", "y", "u", "i", "o", "p"} {
+		ints[k] = len(k)
+		values[k] = k
+	}
+
+	// msgpack sorts the keys of map[string]any, map[string]string and
+	// map[string]bool; CBOR sorts every map.
+	tests := []struct {
+		format Format
+		value  any
+	}{
+		{FormatMsgPack, values},
+		{FormatCBOR, values},
+		{FormatCBOR, ints},
+	}
+
+	for _, tt := range tests {
+		c, _ := New(tt.format)
+		first, _ := c.Marshal(tt.value)
+		for range 10 {
+			again, _ := c.Marshal(tt.value)
+			if !bytes.Equal(first, again) {
+				t.Fatalf("%s: map encoding is not deterministic", tt.format)
+			}
+		}
+	}
+}
+
+type testCodec struct{ jsonCodec }
+
+func TestNewAndRegister(t *testing.T) {
+	if _, err := New("yaml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	Register("test-json", testCodec{})
+	if _, err := New("test-json"); err != nil {
+		t.Fatalf("New registered codec failed: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic when registering a built-in format")
+		}
+	}()
+	Register(FormatJSON, testCodec{})
+}
+
+type inner struct {
+	A int
+}
+
+type Base struct {
+	ID   int
+	Name string
+}
+
+type Extra struct {
+	Note string
+	ID   int
+}
+
+type outer struct {
+	inner
+	*Base
+	B    int
+	Name string
+}
+
+func TestEmbeddedFields(t *testing.T) {
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		c, _ := New(format)
+
+		in := outer{inner: inner{A: 7}, Base: &Base{ID: 3, Name: "shadowed"}, B: 1, Name: "outer"}
+		data, err := c.Marshal(in)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", format, err)
+		}
+
+		var flat map[string]any
+		if err := c.Unmarshal(data, &flat); err != nil {
+			t.Fatalf("%s: Unmarshal into map failed: %v", format, err)
+		}
+		// msgpack decodes every integer as int64, CBOR positive integers as uint64.
+		num := func(n int) any {
+			if format == FormatMsgPack {
+				return int64(n)
+			}
+			return uint64(n)
+		}
+		// Name from outer shadows the deeper Base.Name.
+		want := map[string]any{"A": num(7), "ID": num(3), "B": num(1), "Name": "outer"}
+		if !reflect.DeepEqual(flat, want) {
+			t.Fatalf("%s: embedded fields not flattened: got %#v, want %#v", format, flat, want)
+		}
+
+		var out outer
+		if err := c.Unmarshal(data, &out); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", format, err)
+		}
+		if out.A != 7 || out.B != 1 || out.Name != "outer" || out.Base == nil || out.Base.ID != 3 {
+			t.Fatalf("%s: round trip mismatch: %+v (base %+v)", format, out, out.Base)
+		}
+
+		// A nil embedded pointer is skipped on encode.
+		if _, err := c.Marshal(outer{B: 2}); err != nil {
+			t.Fatalf("%s: Marshal with nil embedded pointer failed: %v", format, err)
+		}
+	}
+}
+
+func TestEmbeddedConflict(t *testing.T) {
+	// ID appears in both embedded structs at the same depth, so CBOR drops it
+	// like encoding/json. msgpack instead keeps the second struct nested.
+	type both struct {
+		Base
+		Extra
+	}
+
+	c, _ := New(FormatCBOR)
+	data, err := c.Marshal(both{Base{ID: 1, Name: "n"}, Extra{Note: "x", ID: 2}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var flat map[string]any
+	if err := c.Unmarshal(data, &flat); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if want := map[string]any{"Name": "n", "Note": "x"}; !reflect.DeepEqual(flat, want) {
+		t.Fatalf("got %#v, want %#v", flat, want)
+	}
+}
+
+func TestPointerTextMarshaler(t *testing.T) {
+	type account struct {
+		Balance big.Int
+		Limits  map[string]*big.Int
+	}
+
+	in := account{Limits: map[string]*big.Int{"daily": big.NewInt(500)}}
+	in.Balance.SetString("123456789012345678901234567890", 10)
+
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		c, _ := New(format)
+
+		data, err := c.Marshal(in)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", format, err)
+		}
+
+		var out account
+		if err := c.Unmarshal(data, &out); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", format, err)
+		}
+		daily := out.Limits["daily"]
+		if out.Balance.Cmp(&in.Balance) != 0 || daily == nil || daily.Int64() != 500 {
+			t.Fatalf("%s: got balance %s, daily %s", format, &out.Balance, daily)
+		}
+	}
+}
+
+func TestNilTextMarshalerInterface(t *testing.T) {
+	type holder struct {
+		T encoding.TextMarshaler
+	}
+
+	for _, format := range []Format{FormatMsgPack, FormatCBOR} {
+		c, _ := New(format)
+
+		data, err := c.Marshal(holder{})
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", format, err)
+		}
+
+		var out holder
+		if err := c.Unmarshal(data, &out); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", format, err)
+		}
+		if out.T != nil {
+			t.Fatalf("%s: got %v, want nil", format, out.T)
+		}
+	}
+}
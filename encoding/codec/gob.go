@@ -0,0 +1,8 @@
+package codec
+
+import "github.com/inovacc/utils/v2/encoding/encoder/gob"
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error)      { return gob.EncodeGob(v) }
+func (gobCodec) Unmarshal(data []byte, v any) error { return gob.DecodeGob(data, v) }
@@ -0,0 +1,41 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// canonicalJSONCodec produces byte-stable JSON: object keys are sorted at every
+// level (including struct fields), there is no insignificant whitespace and
+// HTML characters are not escaped. Numbers keep the text encoding/json gives them.
+type canonicalJSONCodec struct{}
+
+func (canonicalJSONCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through generic values so struct fields are sorted like map keys.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func (canonicalJSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
@@ -0,0 +1,47 @@
+package codec
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// msgpackCodec implements MessagePack (https://msgpack.org) with
+// github.com/vmihailenco/msgpack. Struct fields honour `json` tags when there is
+// no `msgpack` tag and integers use their smallest encoding. The library only
+// sorts the keys of map[string]any, map[string]string and map[string]bool; use
+// CBOR when every map must encode deterministically. Decoding into an interface
+// yields int64 and float64 numbers and map[string]any for maps.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.SetSortMapKeys(true)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(addressable(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	dec.UseLooseInterfaceDecoding(true)
+	return dec.Decode(v)
+}
+
+// addressable returns a pointer to a copy of v unless v is already a pointer,
+// so that fields whose MarshalText has a pointer receiver can be encoded
+func addressable(v any) any {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Kind() == reflect.Pointer {
+		return v
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Interface()
+}
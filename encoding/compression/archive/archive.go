@@ -0,0 +1,67 @@
+// Package archive reads and writes multi-entry archives in zip, tar, tar.gz and
+// tar.zst formats, and extracts them to an afero.Fs with path-traversal protection.
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format defines supported archive formats.
+type Format string
+
+const (
+	FormatZip    Format = "zip"
+	FormatTar    Format = "tar"
+	FormatTarGz  Format = "tar.gz"
+	FormatTarZst Format = "tar.zst"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for an unknown archive Format.
+	ErrUnsupportedFormat = errors.New("unsupported archive format")
+	// ErrUnsafePath is returned when an entry name is absolute or escapes the destination.
+	ErrUnsafePath = errors.New("unsafe archive entry path")
+)
+
+// Entry describes a file or directory stored in an archive.
+type Entry struct {
+	Name    string
+	Size    int64
+	Mode    fs.FileMode
+	ModTime time.Time
+}
+
+// IsDir reports whether the entry is a directory.
+func (e Entry) IsDir() bool {
+	return e.Mode.IsDir()
+}
+
+// cleanName normalizes an entry name to a relative, slash-separated path and
+// rejects names that are absolute or climb out of the archive root.
+func cleanName(name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if path.IsAbs(slashed) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+
+	cleaned := path.Clean(slashed)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %q", ErrUnsafePath, name)
+	}
+	return cleaned, nil
+}
+
+func validFormat(format Format) error {
+	switch format {
+	case FormatZip, FormatTar, FormatTarGz, FormatTarZst:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+var formats = []Format{FormatZip, FormatTar, FormatTarGz, FormatTarZst}
+
+func buildArchive(t *testing.T, format Format, modTime time.Time) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if err := w.AddDir("docs", 0o750, modTime); err != nil {
+		t.Fatalf("AddDir failed: %v", err)
+	}
+	if err := w.AddFile("docs/readme.txt", []byte("read me"), 0o640, modTime); err != nil {
+		t.Fatalf("AddFile failed: %v", err)
+	}
+	if err := w.AddFile("main.go", []byte("package main"), 0o644, modTime); err != nil {
+		t.Fatalf("AddFile failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			data := buildArchive(t, format, modTime)
+
+			r, err := NewReader(bytes.NewReader(data), int64(len(data)), format)
+			if err != nil {
+				t.Fatalf("NewReader failed: %v", err)
+			}
+
+			entries, err := r.Entries()
+			if err != nil {
+				t.Fatalf("Entries failed: %v", err)
+			}
+			if len(entries) != 3 {
+				t.Fatalf("Expected 3 entries, got %d", len(entries))
+			}
+			if !entries[0].IsDir() {
+				t.Errorf("Expected %q to be a directory", entries[0].Name)
+			}
+			if entries[1].Size != int64(len("read me")) {
+				t.Errorf("Expected size %d, got %d", len("read me"), entries[1].Size)
+			}
+			if entries[1].Mode.Perm() != 0o640 {
+				t.Errorf("Expected mode 0640, got %v", entries[1].Mode.Perm())
+			}
+			if !entries[2].ModTime.Equal(modTime) {
+				t.Errorf("Expected mtime %v, got %v", modTime, entries[2].ModTime)
+			}
+
+			fsys := afero.NewMemMapFs()
+			if err := r.Extract(fsys, "out"); err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+
+			content, err := afero.ReadFile(fsys, filepath.Join("out", "docs", "readme.txt"))
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			if string(content) != "read me" {
+				t.Errorf("Expected %q, got %q", "read me", content)
+			}
+
+			info, err := fsys.Stat(filepath.Join("out", "main.go"))
+			if err != nil {
+				t.Fatalf("Stat failed: %v", err)
+			}
+			if !info.ModTime().Equal(modTime) {
+				t.Errorf("Expected extracted mtime %v, got %v", modTime, info.ModTime())
+			}
+		})
+	}
+}
+
+func TestExtractEntry(t *testing.T) {
+	data := buildArchive(t, FormatTarGz, time.Now())
+	r, err := NewReader(bytes.NewReader(data), int64(len(data)), FormatTarGz)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	fsys := afero.NewMemMapFs()
+	if err := r.ExtractEntry(fsys, "out", "main.go"); err != nil {
+		t.Fatalf("ExtractEntry failed: %v", err)
+	}
+	if ok, _ := afero.Exists(fsys, filepath.Join("out", "main.go")); !ok {
+		t.Errorf("Expected main.go to be extracted")
+	}
+	if ok, _ := afero.Exists(fsys, filepath.Join("out", "docs", "readme.txt")); ok {
+		t.Errorf("Expected readme.txt not to be extracted")
+	}
+
+	if err := r.ExtractEntry(fsys, "out", "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist, got %v", err)
+	}
+}
+
+func TestExtractPathTraversal(t *testing.T) {
+	names := []string{"../evil.txt", "/etc/evil.txt", "docs/../../evil.txt"}
+
+	for _, name := range names {
+		t.Run("tar "+name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 4, Typeflag: tar.TypeReg}); err != nil {
+				t.Fatalf("WriteHeader failed: %v", err)
+			}
+			_, _ = tw.Write([]byte("evil"))
+			_ = tw.Close()
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), FormatTar)
+			if err != nil {
+				t.Fatalf("NewReader failed: %v", err)
+			}
+			if err := r.Extract(afero.NewMemMapFs(), "out"); !errors.Is(err, ErrUnsafePath) {
+				t.Errorf("Expected ErrUnsafePath, got %v", err)
+			}
+		})
+
+		t.Run("zip "+name, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			f, err := zw.Create(name)
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			_, _ = f.Write([]byte("evil"))
+			_ = zw.Close()
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), FormatZip)
+			if err != nil {
+				t.Fatalf("NewReader failed: %v", err)
+			}
+			if err := r.Extract(afero.NewMemMapFs(), "out"); !errors.Is(err, ErrUnsafePath) {
+				t.Errorf("Expected ErrUnsafePath, got %v", err)
+			}
+		})
+	}
+
+	w, err := NewWriter(&bytes.Buffer{}, FormatTar)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if err := w.AddFile("../evil.txt", nil, 0o644, time.Now()); !errors.Is(err, ErrUnsafePath) {
+		t.Errorf("Expected ErrUnsafePath from AddFile, got %v", err)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, "rar"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat from NewWriter, got %v", err)
+	}
+	if _, err := NewReader(bytes.NewReader(nil), 0, "rar"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat from NewReader, got %v", err)
+	}
+}
+
+func TestTarHardLinkSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "data.txt", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	_, _ = tw.Write([]byte("data"))
+	if err := tw.WriteHeader(&tar.Header{Name: "link.txt", Linkname: "data.txt", Mode: 0o644, Typeflag: tar.TypeLink}); err != nil {
+		t.Fatalf("WriteHeader failed: %v", err)
+	}
+	_ = tw.Close()
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), FormatTar)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	entries, err := r.Entries()
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name == "link.txt" && e.Mode.IsRegular() {
+			t.Errorf("Expected hard link not to be listed as a regular file")
+		}
+	}
+
+	fsys := afero.NewMemMapFs()
+	if err := r.Extract(fsys, "out"); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if ok, _ := afero.Exists(fsys, filepath.Join("out", "link.txt")); ok {
+		t.Errorf("Expected hard link not to be extracted")
+	}
+	if ok, _ := afero.Exists(fsys, filepath.Join("out", "data.txt")); !ok {
+		t.Errorf("Expected data.txt to be extracted")
+	}
+}
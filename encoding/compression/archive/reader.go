@@ -0,0 +1,225 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/inovacc/utils/v2/encoding/compression/gzip"
+	"github.com/inovacc/utils/v2/encoding/compression/zstd"
+	"github.com/spf13/afero"
+)
+
+// errStop ends a walk early once the requested entry has been handled.
+var errStop = errors.New("stop walk")
+
+// Reader lists and extracts the entries of an archive.
+type Reader struct {
+	format Format
+	ra     io.ReaderAt
+	size   int64
+}
+
+// NewReader creates a Reader for an archive of the given format held in ra.
+// A bytes.Reader or an afero.File (with its size from Stat) can be used as ra.
+func NewReader(ra io.ReaderAt, size int64, format Format) (*Reader, error) {
+	if err := validFormat(format); err != nil {
+		return nil, err
+	}
+	return &Reader{format: format, ra: ra, size: size}, nil
+}
+
+// Entries returns the entries stored in the archive, in archive order.
+func (r *Reader) Entries() ([]Entry, error) {
+	var entries []Entry
+	err := r.walk(func(e Entry, _ io.Reader) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+// Extract writes every file and directory of the archive below dest in fsys.
+// Entries whose names are absolute or escape dest fail with ErrUnsafePath;
+// symbolic links, hard links and other special entries are skipped.
+func (r *Reader) Extract(fsys afero.Fs, dest string) error {
+	return r.walk(func(e Entry, body io.Reader) error {
+		return extractEntry(fsys, dest, e, body)
+	})
+}
+
+// ExtractEntry writes the single entry called name below dest in fsys.
+// It returns an error wrapping fs.ErrNotExist if the archive has no such entry.
+func (r *Reader) ExtractEntry(fsys afero.Fs, dest, name string) error {
+	want, err := cleanName(name)
+	if err != nil {
+		return err
+	}
+
+	err = r.walk(func(e Entry, body io.Reader) error {
+		got, err := cleanName(e.Name)
+		if err != nil || got != want {
+			return nil
+		}
+		if err := extractEntry(fsys, dest, e, body); err != nil {
+			return err
+		}
+		return errStop
+	})
+	switch {
+	case errors.Is(err, errStop):
+		return nil
+	case err != nil:
+		return err
+	default:
+		return fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+}
+
+// walk calls fn for each entry with a reader over its content.
+func (r *Reader) walk(fn func(Entry, io.Reader) error) error {
+	if r.format == FormatZip {
+		return r.walkZip(fn)
+	}
+	return r.walkTar(fn)
+}
+
+func (r *Reader) walkZip(fn func(Entry, io.Reader) error) error {
+	zr, err := zip.NewReader(r.ra, r.size)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		e := Entry{
+			Name:    f.Name,
+			Size:    int64(f.UncompressedSize64),
+			Mode:    f.Mode(),
+			ModTime: f.Modified,
+		}
+		if e.IsDir() {
+			if err := fn(e, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = fn(e, rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reader) walkTar(fn func(Entry, io.Reader) error) error {
+	var src io.Reader = io.NewSectionReader(r.ra, 0, r.size)
+	switch r.format {
+	case FormatTarGz:
+		zr, err := gzip.NewReader(src)
+		if err != nil {
+			return err
+		}
+		defer func(zr io.ReadCloser) {
+			_ = zr.Close()
+		}(zr)
+		src = zr
+	case FormatTarZst:
+		zr, err := zstd.NewReader(src)
+		if err != nil {
+			return err
+		}
+		defer func(zr io.ReadCloser) {
+			_ = zr.Close()
+		}(zr)
+		src = zr
+	}
+
+	tr := tar.NewReader(src)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		mode := hdr.FileInfo().Mode()
+		if hdr.Typeflag == tar.TypeLink || hdr.Typeflag == tar.TypeXGlobalHeader {
+			// FileInfo reports these as regular files; mark them so they are skipped.
+			mode |= fs.ModeIrregular
+		}
+
+		e := Entry{
+			Name:    hdr.Name,
+			Size:    hdr.Size,
+			Mode:    mode,
+			ModTime: hdr.ModTime,
+		}
+		if err := fn(e, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// extractEntry writes one entry below dest, refusing names that escape it.
+func extractEntry(fsys afero.Fs, dest string, e Entry, body io.Reader) error {
+	name, err := cleanName(e.Name)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(dest, filepath.FromSlash(name))
+
+	switch {
+	case e.IsDir():
+		return fsys.MkdirAll(target, dirPerm(e.Mode))
+	case !e.Mode.IsRegular():
+		return nil
+	}
+
+	if err := fsys.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	f, err := fsys.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePerm(e.Mode))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if !e.ModTime.IsZero() {
+		return fsys.Chtimes(target, e.ModTime, e.ModTime)
+	}
+	return nil
+}
+
+func dirPerm(mode fs.FileMode) fs.FileMode {
+	if mode.Perm() == 0 {
+		return 0o755
+	}
+	return mode.Perm()
+}
+
+func filePerm(mode fs.FileMode) fs.FileMode {
+	if mode.Perm() == 0 {
+		return 0o644
+	}
+	return mode.Perm()
+}
@@ -0,0 +1,145 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/inovacc/utils/v2/encoding/compression/gzip"
+	"github.com/inovacc/utils/v2/encoding/compression/zstd"
+)
+
+// Writer adds files and directories to an archive written to an underlying io.Writer.
+type Writer struct {
+	format Format
+	zw     *zip.Writer
+	tw     *tar.Writer
+	comp   io.WriteCloser
+}
+
+// NewWriter creates a Writer that writes an archive of the given format into w.
+// The caller must Close the Writer to finalize the archive; closing it does not close w.
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	if err := validFormat(format); err != nil {
+		return nil, err
+	}
+
+	aw := &Writer{format: format}
+	switch format {
+	case FormatZip:
+		aw.zw = zip.NewWriter(w)
+		return aw, nil
+	case FormatTarGz:
+		comp, err := gzip.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		aw.comp, w = comp, comp
+	case FormatTarZst:
+		comp, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		aw.comp, w = comp, comp
+	}
+	aw.tw = tar.NewWriter(w)
+	return aw, nil
+}
+
+// AddFile stores data as a regular file entry.
+func (w *Writer) AddFile(name string, data []byte, mode fs.FileMode, modTime time.Time) error {
+	return w.AddReader(Entry{
+		Name:    name,
+		Size:    int64(len(data)),
+		Mode:    mode.Perm(),
+		ModTime: modTime,
+	}, bytes.NewReader(data))
+}
+
+// AddDir stores a directory entry.
+func (w *Writer) AddDir(name string, mode fs.FileMode, modTime time.Time) error {
+	return w.AddReader(Entry{
+		Name:    name,
+		Mode:    fs.ModeDir | mode.Perm(),
+		ModTime: modTime,
+	}, nil)
+}
+
+// AddReader streams a regular file entry from r, or stores a directory when
+// e.Mode is a directory (r is then ignored). Tar formats record e.Size up front,
+// so r must yield exactly that many bytes.
+func (w *Writer) AddReader(e Entry, r io.Reader) error {
+	name, err := cleanName(e.Name)
+	if err != nil {
+		return err
+	}
+
+	if w.zw != nil {
+		return w.addZip(name, e, r)
+	}
+	return w.addTar(name, e, r)
+}
+
+func (w *Writer) addZip(name string, e Entry, r io.Reader) error {
+	hdr := &zip.FileHeader{
+		Name:     name,
+		Modified: e.ModTime,
+		Method:   zip.Deflate,
+	}
+	hdr.SetMode(e.Mode)
+	if e.IsDir() {
+		hdr.Name += "/"
+		hdr.Method = zip.Store
+	}
+
+	f, err := w.zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	if e.IsDir() {
+		return nil
+	}
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (w *Writer) addTar(name string, e Entry, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     int64(e.Mode.Perm()),
+		ModTime:  e.ModTime,
+		Size:     e.Size,
+		Typeflag: tar.TypeReg,
+	}
+	if e.IsDir() {
+		hdr.Name += "/"
+		hdr.Size = 0
+		hdr.Typeflag = tar.TypeDir
+	}
+
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if e.IsDir() {
+		return nil
+	}
+	_, err := io.Copy(w.tw, r)
+	return err
+}
+
+// Close finalizes the archive and flushes any compression layer.
+func (w *Writer) Close() error {
+	if w.zw != nil {
+		return w.zw.Close()
+	}
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	if w.comp != nil {
+		return w.comp.Close()
+	}
+	return nil
+}
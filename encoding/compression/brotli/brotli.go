@@ -1,26 +1,71 @@
 package brotli
 
 import (
-	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/andybalholm/brotli"
 )
 
-func Compress(data []byte) ([]byte, error) {
-	var b bytes.Buffer
-	w := brotli.NewWriter(&b)
-	_, err := w.Write(data)
-	if err != nil {
-		return nil, err
+// Option configures the brotli writer used by Compress and NewWriter.
+type Option func(*options)
+
+type options struct {
+	quality int
+	lgwin   int
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := options{quality: brotli.DefaultCompression}
+	for _, opt := range opts {
+		opt(&o)
 	}
-	if err := w.Close(); err != nil {
-		return nil, err
+	if o.quality < brotli.BestSpeed || o.quality > brotli.BestCompression {
+		return o, fmt.Errorf("brotli: invalid quality %d", o.quality)
+	}
+	if o.lgwin != 0 && (o.lgwin < 10 || o.lgwin > 24) {
+		return o, fmt.Errorf("brotli: invalid window %d", o.lgwin)
+	}
+	return o, nil
+}
+
+// WithQuality sets the compression quality, from brotli.BestSpeed (0)
+// up to brotli.BestCompression (11).
+func WithQuality(quality int) Option {
+	return func(o *options) {
+		o.quality = quality
+	}
+}
+
+// WithWindow sets the base 2 logarithm of the sliding window size (10-24).
+// A value of 0 selects the window automatically from the quality.
+func WithWindow(lgwin int) Option {
+	return func(o *options) {
+		o.lgwin = lgwin
 	}
-	return b.Bytes(), nil
 }
 
+// Compress compresses data, reusing pooled writers between calls.
+func Compress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendCompress(nil, data, opts...)
+}
+
+// Decompress decompresses data, reusing pooled readers between calls.
 func Decompress(data []byte) ([]byte, error) {
-	r := brotli.NewReader(bytes.NewReader(data))
-	return io.ReadAll(r)
+	return AppendDecompress(nil, data)
+}
+
+// NewWriter returns a brotli writer that compresses everything written to it into w.
+// The caller must Close the writer to flush the final meta-block.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return brotli.NewWriterOptions(w, brotli.WriterOptions{Quality: o.quality, LGWin: o.lgwin}), nil
+}
+
+// NewReader returns a brotli reader that decompresses the stream read from r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(brotli.NewReader(r)), nil
 }
@@ -0,0 +1,76 @@
+package brotli
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Writers keep their options across Reset, so there is one pool per option set.
+var (
+	writerPools sync.Map // options -> *sync.Pool
+	readerPool  sync.Pool
+)
+
+func writerPool(o options) *sync.Pool {
+	if p, ok := writerPools.Load(o); ok {
+		return p.(*sync.Pool)
+	}
+	p, _ := writerPools.LoadOrStore(o, &sync.Pool{})
+	return p.(*sync.Pool)
+}
+
+func getWriter(w io.Writer, o options) *brotli.Writer {
+	if bw, ok := writerPool(o).Get().(*brotli.Writer); ok {
+		bw.Reset(w)
+		return bw
+	}
+	return brotli.NewWriterOptions(w, brotli.WriterOptions{Quality: o.quality, LGWin: o.lgwin})
+}
+
+func getReader(r io.Reader) (*brotli.Reader, error) {
+	if br, ok := readerPool.Get().(*brotli.Reader); ok {
+		if err := br.Reset(r); err != nil {
+			return nil, err
+		}
+		return br, nil
+	}
+	return brotli.NewReader(r), nil
+}
+
+// AppendCompress appends the brotli stream of src to dst and returns the extended slice.
+func AppendCompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(dst)
+	bw := getWriter(buf, o)
+	if _, err := bw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := bw.Close(); err != nil {
+		return nil, err
+	}
+	bw.Reset(io.Discard)
+	writerPool(o).Put(bw)
+	return buf.Bytes(), nil
+}
+
+// AppendDecompress appends the decompressed contents of src to dst and returns
+// the extended slice.
+func AppendDecompress(dst, src []byte) ([]byte, error) {
+	br, err := getReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(dst)
+	if _, err := buf.ReadFrom(br); err != nil {
+		return nil, err
+	}
+	_ = br.Reset(nil)
+	readerPool.Put(br)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,281 @@
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// DefaultChunkSize is the block size used by CompressChunked when none is given.
+const DefaultChunkSize = 1 << 20
+
+// ErrCorruptChunked is returned when a chunked container is malformed.
+var ErrCorruptChunked = errors.New("corrupt chunked container")
+
+// ErrChunkTooLarge is returned by CompressChunked when the block size, the
+// number of blocks or a compressed block does not fit the container's uint32 fields.
+var ErrChunkTooLarge = errors.New("chunk too large for chunked container")
+
+// chunkedMagic starts every chunked container.
+//
+// Layout (all integers big-endian):
+//
+//	magic      [4]byte  "UCHK"
+//	version    uint8
+//	typeLen    uint8
+//	type       [typeLen]byte
+//	blockSize  uint32   uncompressed size of every block but the last
+//	size       uint64   total uncompressed size
+//	count      uint32   number of blocks
+//	index      [count]uint32 compressed length of each block
+//	blocks     independently compressed blocks, in order
+var chunkedMagic = []byte("UCHK")
+
+const chunkedVersion = 1
+
+// CompressChunked splits data into blocks of blockSize bytes, compresses them
+// in parallel with the configured algorithm and writes them into a framed
+// container with a block index. Because blocks are independent, any byte range
+// can later be read through NewChunkedReader without inflating the whole payload.
+// A blockSize of 0 or less uses DefaultChunkSize.
+func (c *Compress) CompressChunked(data []byte, blockSize int) ([]byte, error) {
+	if blockSize <= 0 {
+		blockSize = DefaultChunkSize
+	}
+	if uint64(blockSize) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: block size %d", ErrChunkTooLarge, blockSize)
+	}
+	if len(c.Type) > 255 {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, c.Type)
+	}
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+
+	count := (len(data) + blockSize - 1) / blockSize
+	if uint64(count) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: %d blocks", ErrChunkTooLarge, count)
+	}
+	blocks := make([][]byte, count)
+	err = parallel(count, func(i int) error {
+		start := i * blockSize
+		end := min(start+blockSize, len(data))
+		block, err := codec.Compress(data[start:end])
+		if err == nil && uint64(len(block)) > math.MaxUint32 {
+			err = fmt.Errorf("%w: block %d compressed to %d bytes", ErrChunkTooLarge, i, len(block))
+		}
+		blocks[i] = block
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(chunkedMagic)
+	buf.WriteByte(chunkedVersion)
+	buf.WriteByte(byte(len(c.Type)))
+	buf.WriteString(string(c.Type))
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(blockSize)))
+	buf.Write(binary.BigEndian.AppendUint64(nil, uint64(len(data))))
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(count)))
+	for _, block := range blocks {
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(block))))
+	}
+	for _, block := range blocks {
+		buf.Write(block)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressChunked decompresses a whole chunked container, inflating blocks in
+// parallel. Options are applied to the algorithm recorded in the container.
+func DecompressChunked(data []byte, opts ...Option) ([]byte, error) {
+	r, err := NewChunkedReader(data, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks are inflated into their own buffers and joined afterwards, so memory
+	// grows with the verified block lengths rather than the size the header claims.
+	blocks := make([][]byte, len(r.offsets))
+	err = parallel(len(r.offsets), func(i int) error {
+		block, err := r.block(i)
+		blocks[i] = block
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, block := range blocks {
+		total += len(block)
+	}
+	out := make([]byte, 0, total)
+	for _, block := range blocks {
+		out = append(out, block...)
+	}
+	return out, nil
+}
+
+// ChunkedReader provides random access to the uncompressed content of a
+// chunked container, decompressing only the blocks a read touches.
+type ChunkedReader struct {
+	codec     Codec
+	typ       TypeStr
+	blockSize int
+	size      int64
+	offsets   []int
+	lengths   []int
+	data      []byte
+}
+
+// NewChunkedReader parses the header and block index of a container produced by
+// CompressChunked. Options are applied to the algorithm recorded in the container;
+// WithMaxSize and WithMaxRatio are checked against the recorded total size, and
+// no block is ever inflated past its recorded length.
+func NewChunkedReader(data []byte, opts ...Option) (*ChunkedReader, error) {
+	rest, ok := bytes.CutPrefix(data, chunkedMagic)
+	if !ok || len(rest) < 2 || rest[0] != chunkedVersion {
+		return nil, ErrCorruptChunked
+	}
+	typeLen := int(rest[1])
+	rest = rest[2:]
+	if len(rest) < typeLen+16 {
+		return nil, ErrCorruptChunked
+	}
+
+	r := &ChunkedReader{typ: TypeStr(rest[:typeLen])}
+	rest = rest[typeLen:]
+	r.blockSize = int(binary.BigEndian.Uint32(rest[0:4]))
+	size := binary.BigEndian.Uint64(rest[4:12])
+	count := int(binary.BigEndian.Uint32(rest[12:16]))
+	rest = rest[16:]
+
+	if r.blockSize == 0 || size > uint64(count)*uint64(r.blockSize) ||
+		(count > 0 && size <= uint64(count-1)*uint64(r.blockSize)) || len(rest)/4 < count {
+		return nil, ErrCorruptChunked
+	}
+	r.size = int64(size)
+
+	c := NewCompress(r.typ, opts...)
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	r.codec = codec
+	if err := c.checkOutput(r.size, len(data)); err != nil {
+		return nil, err
+	}
+
+	r.offsets = make([]int, count)
+	r.lengths = make([]int, count)
+	offset := 0
+	for i := range count {
+		r.offsets[i] = offset
+		r.lengths[i] = int(binary.BigEndian.Uint32(rest[i*4:]))
+		if r.lengths[i] == 0 {
+			return nil, ErrCorruptChunked
+		}
+		offset += r.lengths[i]
+	}
+	r.data = rest[count*4:]
+	if offset != len(r.data) {
+		return nil, ErrCorruptChunked
+	}
+	return r, nil
+}
+
+// Type returns the compression algorithm recorded in the container.
+func (r *ChunkedReader) Type() TypeStr {
+	return r.typ
+}
+
+// Size returns the total uncompressed size.
+func (r *ChunkedReader) Size() int64 {
+	return r.size
+}
+
+// ReadAt implements io.ReaderAt over the uncompressed content. Every call
+// decompresses the blocks it touches, so sequential readers should read whole
+// blocks at a time. It is safe for concurrent use.
+func (r *ChunkedReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	n := 0
+	for n < len(p) && off < r.size {
+		i := int(off / int64(r.blockSize))
+		block, err := r.block(i)
+		if err != nil {
+			return n, err
+		}
+		copied := copy(p[n:], block[off-int64(i)*int64(r.blockSize):])
+		n += copied
+		off += int64(copied)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// block decompresses block i and checks it has the expected length.
+func (r *ChunkedReader) block(i int) ([]byte, error) {
+	start := r.offsets[i]
+	want := min(int64(r.blockSize), r.size-int64(i)*int64(r.blockSize))
+	block, err := decompressChecked(r.codec, r.data[start:start+r.lengths[i]], func(n int64) error {
+		if n > want {
+			return ErrCorruptChunked
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(block)) != want {
+		return nil, ErrCorruptChunked
+	}
+	return block, nil
+}
+
+// parallel runs fn for every index in [0, n) on up to GOMAXPROCS goroutines
+// and returns the first error encountered.
+func parallel(n int, fn func(i int) error) error {
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		next     = make(chan int)
+	)
+
+	for range min(n, runtime.GOMAXPROCS(0)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := fn(i); err != nil {
+					once.Do(func() { firstErr = err })
+				}
+			}
+		}()
+	}
+
+	for i := range n {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return firstErr
+}
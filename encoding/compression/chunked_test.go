@@ -0,0 +1,146 @@
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+)
+
+func chunkedPayload() []byte {
+	var buf bytes.Buffer
+	for i := range 20000 {
+		fmt.Fprintf(&buf, "line %05d of the chunked payload\n", i)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressChunked(t *testing.T) {
+	payload := chunkedPayload()
+
+	for _, typ := range []TypeStr{TypeZstd, TypeGzip, TypeSnappy, TypeLz4, TypeBrotli, TypeZlib, TypeZip} {
+		t.Run(string(typ), func(t *testing.T) {
+			data, err := NewCompress(typ).CompressChunked(payload, 64<<10)
+			if err != nil {
+				t.Fatalf("CompressChunked failed: %v", err)
+			}
+
+			decompressed, err := DecompressChunked(data)
+			if err != nil {
+				t.Fatalf("DecompressChunked failed: %v", err)
+			}
+			if !bytes.Equal(decompressed, payload) {
+				t.Fatalf("Decompressed data does not match original data")
+			}
+		})
+	}
+}
+
+func TestChunkedReaderReadAt(t *testing.T) {
+	payload := chunkedPayload()
+	data, err := NewCompress(TypeZstd).CompressChunked(payload, 4096)
+	if err != nil {
+		t.Fatalf("CompressChunked failed: %v", err)
+	}
+
+	r, err := NewChunkedReader(data)
+	if err != nil {
+		t.Fatalf("NewChunkedReader failed: %v", err)
+	}
+	if r.Type() != TypeZstd {
+		t.Errorf("Expected %s, got %s", TypeZstd, r.Type())
+	}
+	if r.Size() != int64(len(payload)) {
+		t.Fatalf("Expected size %d, got %d", len(payload), r.Size())
+	}
+
+	ranges := []struct{ off, length int64 }{
+		{0, 10},
+		{4090, 20},
+		{100000, 9000},
+		{int64(len(payload)) - 5, 5},
+	}
+	for _, rg := range ranges {
+		got := make([]byte, rg.length)
+		if _, err := r.ReadAt(got, rg.off); err != nil {
+			t.Fatalf("ReadAt(%d, %d) failed: %v", rg.off, rg.length, err)
+		}
+		if want := payload[rg.off : rg.off+rg.length]; !bytes.Equal(got, want) {
+			t.Fatalf("ReadAt(%d, %d): expected %q, got %q", rg.off, rg.length, want, got)
+		}
+	}
+
+	tail := make([]byte, 10)
+	n, err := r.ReadAt(tail, int64(len(payload))-4)
+	if n != 4 || !errors.Is(err, io.EOF) {
+		t.Errorf("Expected 4 bytes and io.EOF at the tail, got %d and %v", n, err)
+	}
+
+	section, err := io.ReadAll(io.NewSectionReader(r, 500, 1000))
+	if err != nil {
+		t.Fatalf("SectionReader failed: %v", err)
+	}
+	if !bytes.Equal(section, payload[500:1500]) {
+		t.Errorf("SectionReader returned unexpected content")
+	}
+}
+
+func TestCompressChunkedEmpty(t *testing.T) {
+	data, err := NewCompress(TypeGzip).CompressChunked(nil, 0)
+	if err != nil {
+		t.Fatalf("CompressChunked failed: %v", err)
+	}
+
+	decompressed, err := DecompressChunked(data)
+	if err != nil {
+		t.Fatalf("DecompressChunked failed: %v", err)
+	}
+	if len(decompressed) != 0 {
+		t.Errorf("Expected empty output, got %d bytes", len(decompressed))
+	}
+}
+
+func TestCompressChunkedBlockSizeOverflow(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("block sizes over 4 GiB need a 64-bit int")
+	}
+	shift := 32
+	_, err := NewCompress(TypeGzip).CompressChunked([]byte("data"), 1<<shift)
+	if !errors.Is(err, ErrChunkTooLarge) {
+		t.Fatalf("Expected ErrChunkTooLarge, got %v", err)
+	}
+}
+
+func TestChunkedCorrupt(t *testing.T) {
+	data, err := NewCompress(TypeLz4).CompressChunked(chunkedPayload(), 8192)
+	if err != nil {
+		t.Fatalf("CompressChunked failed: %v", err)
+	}
+
+	if _, err := NewChunkedReader([]byte("not chunked")); !errors.Is(err, ErrCorruptChunked) {
+		t.Errorf("Expected ErrCorruptChunked for bad magic, got %v", err)
+	}
+	if _, err := NewChunkedReader(data[:len(data)-1]); !errors.Is(err, ErrCorruptChunked) {
+		t.Errorf("Expected ErrCorruptChunked for truncated data, got %v", err)
+	}
+
+	// A forged header claiming a 4 GiB payload backed by one empty block.
+	forged := []byte("UCHK\x01\x04zstd")
+	forged = binary.BigEndian.AppendUint32(forged, 0xFFFFFFFF)
+	forged = binary.BigEndian.AppendUint64(forged, 0xFFFFFFFF)
+	forged = binary.BigEndian.AppendUint32(forged, 1)
+	forged = binary.BigEndian.AppendUint32(forged, 0)
+	if _, err := NewChunkedReader(forged); !errors.Is(err, ErrCorruptChunked) {
+		t.Errorf("Expected ErrCorruptChunked for empty block, got %v", err)
+	}
+	if _, err := DecompressChunked(forged); !errors.Is(err, ErrCorruptChunked) {
+		t.Errorf("Expected ErrCorruptChunked from DecompressChunked, got %v", err)
+	}
+
+	if _, err := NewCompress("unknown").CompressChunked([]byte("test"), 0); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/inovacc/utils/v2/encoding/compression/brotli"
+	"github.com/inovacc/utils/v2/encoding/compression/gzip"
+	"github.com/inovacc/utils/v2/encoding/compression/lz4"
+	"github.com/inovacc/utils/v2/encoding/compression/snappy"
+	"github.com/inovacc/utils/v2/encoding/compression/zip"
+	"github.com/inovacc/utils/v2/encoding/compression/zlib"
+	"github.com/inovacc/utils/v2/encoding/compression/zstd"
+)
+
+// ErrUnsupportedType is returned when a Compress uses a type that is neither
+// built in nor registered.
+var ErrUnsupportedType = errors.New("unsupported compression type")
+
+// Codec is a compression algorithm that can be plugged into Compress via Register.
+// A codec that only supports one direction (for example bzip2, which can only be
+// decoded) should return an error from the methods it cannot honor.
+//
+// A codec may also implement Open(data []byte) (io.ReadCloser, error), streaming
+// the content Decompress would return, so WithMaxSize and WithMaxRatio can stop
+// decompression early; otherwise limits are checked after Decompress returns.
+type Codec interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[TypeStr]Codec)
+)
+
+// Register makes a codec available to Compress under the given name.
+// It panics if the name is empty, the codec is nil, or the name is already
+// taken by a built-in or previously registered codec.
+func Register(name TypeStr, codec Codec) {
+	if name == "" {
+		panic("compression: Register name is empty")
+	}
+	if codec == nil {
+		panic("compression: Register codec is nil")
+	}
+	if isBuiltin(name) {
+		panic(fmt.Sprintf("compression: Register called for built-in type %q", name))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("compression: Register called twice for type %q", name))
+	}
+	registry[name] = codec
+}
+
+// lookup returns the registered codec for name, if any.
+func lookup(name TypeStr) (Codec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	codec, ok := registry[name]
+	return codec, ok
+}
+
+func isBuiltin(t TypeStr) bool {
+	return slices.Contains(builtinTypes, t)
+}
+
+// codecFuncs adapts the function-based subpackages to the Codec interface.
+type codecFuncs struct {
+	compress   func([]byte) ([]byte, error)
+	decompress func([]byte) ([]byte, error)
+	newWriter  func(io.Writer) (io.WriteCloser, error)
+	newReader  func(io.Reader) (io.ReadCloser, error)
+	open       func([]byte) (io.ReadCloser, error)
+}
+
+func (f codecFuncs) Compress(data []byte) ([]byte, error)          { return f.compress(data) }
+func (f codecFuncs) Decompress(data []byte) ([]byte, error)        { return f.decompress(data) }
+func (f codecFuncs) NewWriter(w io.Writer) (io.WriteCloser, error) { return f.newWriter(w) }
+func (f codecFuncs) NewReader(r io.Reader) (io.ReadCloser, error)  { return f.newReader(r) }
+func (f codecFuncs) Open(data []byte) (io.ReadCloser, error)       { return f.open(data) }
+
+// reader adapts a stream constructor into an opener for whole buffers.
+func reader(newReader func(io.Reader) (io.ReadCloser, error)) func([]byte) (io.ReadCloser, error) {
+	return func(data []byte) (io.ReadCloser, error) {
+		return newReader(bytes.NewReader(data))
+	}
+}
+
+// snappyCodec is the snappy block codec; its data records the decoded length up front.
+type snappyCodec struct{}
+
+func (snappyCodec) Compress(data []byte) ([]byte, error)          { return snappy.Compress(data) }
+func (snappyCodec) Decompress(data []byte) ([]byte, error)        { return snappy.Decompress(data) }
+func (snappyCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return snappy.NewWriter(w) }
+func (snappyCodec) NewReader(r io.Reader) (io.ReadCloser, error)  { return snappy.NewReader(r) }
+func (snappyCodec) DecodedLen(data []byte) (int, error)           { return snappy.DecodedLen(data) }
+
+// codec resolves the Codec for the compression type, binding the configured options
+// to built-in algorithms and falling back to the registry for everything else.
+func (c *Compress) codec() (Codec, error) {
+	switch c.Type {
+	case TypeZstd:
+		opts := c.zstdOptions()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return zstd.Compress(data, opts...) },
+			decompress: func(data []byte) ([]byte, error) { return zstd.Decompress(data, opts...) },
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w, opts...) },
+			newReader:  func(r io.Reader) (io.ReadCloser, error) { return zstd.NewReader(r, opts...) },
+			open:       reader(func(r io.Reader) (io.ReadCloser, error) { return zstd.NewReader(r, opts...) }),
+		}, nil
+	case TypeGzip:
+		opts := c.gzipOptions()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return gzip.Compress(data, opts...) },
+			decompress: gzip.Decompress,
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w, opts...) },
+			newReader:  gzip.NewReader,
+			open:       reader(gzip.NewReader),
+		}, nil
+	case TypeSnappy:
+		return snappyCodec{}, nil
+	case TypeLz4:
+		opts := c.lz4Options()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return lz4.Compress(data, opts...) },
+			decompress: lz4.Decompress,
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return lz4.NewWriter(w, opts...) },
+			newReader:  lz4.NewReader,
+			open:       reader(lz4.NewReader),
+		}, nil
+	case TypeBrotli:
+		opts := c.brotliOptions()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return brotli.Compress(data, opts...) },
+			decompress: brotli.Decompress,
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return brotli.NewWriter(w, opts...) },
+			newReader:  brotli.NewReader,
+			open:       reader(brotli.NewReader),
+		}, nil
+	case TypeZlib:
+		opts := c.zlibOptions()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return zlib.Compress(data, opts...) },
+			decompress: zlib.Decompress,
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return zlib.NewWriter(w, opts...) },
+			newReader:  zlib.NewReader,
+			open:       reader(zlib.NewReader),
+		}, nil
+	case TypeZip:
+		opts := c.zipOptions()
+		return codecFuncs{
+			compress:   func(data []byte) ([]byte, error) { return zip.Compress(data, opts...) },
+			decompress: zip.Decompress,
+			newWriter:  func(w io.Writer) (io.WriteCloser, error) { return zip.NewWriter(w, opts...) },
+			newReader:  zip.NewReader,
+			open:       zip.Open,
+		}, nil
+	}
+
+	if codec, ok := lookup(c.Type); ok {
+		return codec, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, c.Type)
+}
@@ -0,0 +1,86 @@
+package compression
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// reverseCodec is a toy codec that stores data reversed.
+type reverseCodec struct{}
+
+func reversed(data []byte) []byte {
+	out := make([]byte, len(data))
+	for i, b := range data {
+		out[len(data)-1-i] = b
+	}
+	return out
+}
+
+func (reverseCodec) Compress(data []byte) ([]byte, error)   { return reversed(data), nil }
+func (reverseCodec) Decompress(data []byte) ([]byte, error) { return reversed(data), nil }
+
+func (reverseCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nil, ErrUnsupportedType
+}
+
+func (reverseCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return nil, ErrUnsupportedType
+}
+
+func TestRegister(t *testing.T) {
+	const typeReverse TypeStr = "reverse"
+	Register(typeReverse, reverseCodec{})
+
+	c := NewCompress(typeReverse)
+	if c.TypeString() != string(typeReverse) {
+		t.Fatalf("Expected %q, got %q", typeReverse, c.TypeString())
+	}
+
+	compressed, err := c.Compress([]byte("abc"))
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+	if string(compressed) != "cba" {
+		t.Fatalf("Expected %q, got %q", "cba", compressed)
+	}
+
+	decompressed, err := c.Decompress(compressed)
+	if err != nil {
+		t.Fatalf("Decompress failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, []byte("abc")) {
+		t.Fatalf("Expected %q, got %q", "abc", decompressed)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   TypeStr
+		codec Codec
+	}{
+		{"empty name", "", reverseCodec{}},
+		{"nil codec", "nil-codec", nil},
+		{"built-in", TypeGzip, reverseCodec{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected Register to panic")
+				}
+			}()
+			Register(tt.typ, tt.codec)
+		})
+	}
+
+	Register("duplicate", reverseCodec{})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected duplicate Register to panic")
+		}
+	}()
+	Register("duplicate", reverseCodec{})
+}
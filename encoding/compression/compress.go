@@ -1,13 +1,7 @@
 package compression
 
 import (
-	"github.com/inovacc/utils/v2/encoding/compression/brotli"
-	"github.com/inovacc/utils/v2/encoding/compression/gzip"
-	"github.com/inovacc/utils/v2/encoding/compression/lz4"
-	"github.com/inovacc/utils/v2/encoding/compression/snappy"
-	"github.com/inovacc/utils/v2/encoding/compression/zip"
-	"github.com/inovacc/utils/v2/encoding/compression/zlib"
-	"github.com/inovacc/utils/v2/encoding/compression/zstd"
+	"io"
 )
 
 // TypeStr defines supported compression algorithm names.
@@ -23,58 +17,88 @@ const (
 	TypeZip    TypeStr = "zip"
 )
 
+// builtinTypes lists the built-in algorithms in a stable order.
+var builtinTypes = []TypeStr{TypeZstd, TypeGzip, TypeSnappy, TypeLz4, TypeBrotli, TypeZlib, TypeZip}
+
 // Compress holds a compression type and provides methods to compress/decompress data.
 type Compress struct {
 	Type TypeStr
+
+	level       *int
+	preset      preset
+	concurrency *int
+	window      int
+	blockSize   int
+	dict        []byte
+	maxSize     int64
+	maxRatio    float64
 }
 
 // NewCompress creates a new Compress instance with the specified compression type.
-func NewCompress(t TypeStr) *Compress {
-	return &Compress{Type: t}
+// Options tune the encoder, for example its level or concurrency.
+func NewCompress(t TypeStr, opts ...Option) *Compress {
+	c := &Compress{Type: t}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Compress compresses the input byte slice using the specified compression algorithm.
+// It returns ErrUnsupportedType if the type is neither built in nor registered.
 func (c *Compress) Compress(data []byte) ([]byte, error) {
-	switch c.Type {
-	case TypeZstd:
-		return zstd.Compress(data)
-	case TypeGzip:
-		return gzip.Compress(data)
-	case TypeSnappy:
-		return snappy.Compress(data)
-	case TypeLz4:
-		return lz4.Compress(data)
-	case TypeBrotli:
-		return brotli.Compress(data)
-	case TypeZlib:
-		return zlib.Compress(data)
-	case TypeZip:
-		return zip.Compress(data)
-	default:
-		return nil, nil
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
 	}
+	return codec.Compress(data)
 }
 
 // Decompress decompresses the input byte slice using the specified compression algorithm.
+// It returns ErrUnsupportedType if the type is neither built in nor registered,
+// and a *LimitError if the output exceeds WithMaxSize or WithMaxRatio.
 func (c *Compress) Decompress(data []byte) ([]byte, error) {
-	switch c.Type {
-	case TypeZstd:
-		return zstd.Decompress(data)
-	case TypeGzip:
-		return gzip.Decompress(data)
-	case TypeSnappy:
-		return snappy.Decompress(data)
-	case TypeLz4:
-		return lz4.Decompress(data)
-	case TypeBrotli:
-		return brotli.Decompress(data)
-	case TypeZlib:
-		return zlib.Decompress(data)
-	case TypeZip:
-		return zip.Decompress(data)
-	default:
-		return nil, nil
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	if c.limited() {
+		return decompressChecked(codec, data, func(n int64) error {
+			return c.checkOutput(n, len(data))
+		})
 	}
+	return codec.Decompress(data)
+}
+
+// NewWriter returns a writer that compresses everything written to it into w
+// using the specified compression algorithm. The caller must Close the writer
+// to flush any buffered data; closing it does not close w.
+func (c *Compress) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	return codec.NewWriter(w)
+}
+
+// NewReader returns a reader that decompresses the stream read from r
+// using the specified compression algorithm. Closing it does not close r.
+// Reads fail with a *LimitError once the output exceeds WithMaxSize or WithMaxRatio.
+func (c *Compress) NewReader(r io.Reader) (io.ReadCloser, error) {
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	if !c.limited() {
+		return codec.NewReader(r)
+	}
+
+	in := &countingReader{r: r}
+	rc, err := codec.NewReader(in)
+	if err != nil {
+		return nil, err
+	}
+	return &limitedReader{ReadCloser: rc, c: c, in: in}, nil
 }
 
 // String returns the name of the compression type.
@@ -82,12 +106,14 @@ func (c *Compress) String() string {
 	return c.TypeString()
 }
 
-// TypeString returns the string representation of the compression type.
+// TypeString returns the string representation of the compression type,
+// or an empty string if it is neither built in nor registered.
 func (c *Compress) TypeString() string {
-	switch c.Type {
-	case TypeZstd, TypeGzip, TypeSnappy, TypeLz4, TypeBrotli, TypeZlib, TypeZip:
+	if isBuiltin(c.Type) {
+		return string(c.Type)
+	}
+	if _, ok := lookup(c.Type); ok {
 		return string(c.Type)
-	default:
-		return ""
 	}
+	return ""
 }
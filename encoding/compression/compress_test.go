@@ -2,7 +2,11 @@ package compression
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"testing"
+
+	"github.com/inovacc/utils/v2/encoding/compression/zstd"
 )
 
 func TestNewCompress(t *testing.T) {
@@ -209,3 +213,169 @@ func TestNewCompress(t *testing.T) {
 		return
 	}
 }
+
+func TestCompressStream(t *testing.T) {
+	types := []TypeStr{TypeZstd, TypeGzip, TypeSnappy, TypeLz4, TypeBrotli, TypeZlib, TypeZip}
+	payload := bytes.Repeat([]byte("stream test "), 4096)
+
+	for _, typ := range types {
+		t.Run(string(typ), func(t *testing.T) {
+			c := NewCompress(typ)
+
+			var buf bytes.Buffer
+			w, err := c.NewWriter(&buf)
+			if err != nil {
+				t.Fatalf("NewWriter failed: %v", err)
+			}
+			if _, err := io.Copy(w, bytes.NewReader(payload)); err != nil {
+				t.Fatalf("Copy failed: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			r, err := c.NewReader(&buf)
+			if err != nil {
+				t.Fatalf("NewReader failed: %v", err)
+			}
+			defer func(r io.ReadCloser) {
+				_ = r.Close()
+			}(r)
+
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll failed: %v", err)
+			}
+			if !bytes.Equal(got, payload) {
+				t.Fatalf("Decompressed stream does not match original data")
+			}
+		})
+	}
+}
+
+func TestCompressStreamZipInterop(t *testing.T) {
+	payload := []byte("archive written in one shot, read as a stream")
+	data, err := NewCompress(TypeZip).Compress(payload)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+
+	r, err := NewCompress(TypeZip).NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("Expected %q, got %q", payload, got)
+	}
+}
+
+func TestCompressUnsupported(t *testing.T) {
+	c := NewCompress("unknown")
+	if _, err := c.Compress([]byte("test")); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType from Compress, got %v", err)
+	}
+	if _, err := c.Decompress([]byte("test")); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType from Decompress, got %v", err)
+	}
+	if _, err := c.NewWriter(io.Discard); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType from NewWriter, got %v", err)
+	}
+	if _, err := c.NewReader(bytes.NewReader(nil)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType from NewReader, got %v", err)
+	}
+	if c.TypeString() != "" {
+		t.Errorf("Expected empty TypeString, got %q", c.TypeString())
+	}
+}
+
+func TestCompressOptions(t *testing.T) {
+	payload := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog "), 2048)
+
+	tests := []struct {
+		typ  TypeStr
+		opts []Option
+	}{
+		{TypeZstd, []Option{WithBestCompression(), WithConcurrency(2), WithWindow(20)}},
+		{TypeZstd, []Option{WithLevel(3)}},
+		{TypeGzip, []Option{WithFastest()}},
+		{TypeGzip, []Option{WithLevel(9)}},
+		{TypeZlib, []Option{WithBestCompression()}},
+		{TypeZip, []Option{WithLevel(1)}},
+		{TypeLz4, []Option{WithBestCompression(), WithBlockSize(64 << 10), WithConcurrency(1)}},
+		{TypeBrotli, []Option{WithLevel(11), WithWindow(22)}},
+		{TypeSnappy, []Option{WithBestCompression()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			c := NewCompress(tt.typ, tt.opts...)
+			compressed, err := c.Compress(payload)
+			if err != nil {
+				t.Fatalf("Compress failed: %v", err)
+			}
+
+			decompressed, err := c.Decompress(compressed)
+			if err != nil {
+				t.Fatalf("Decompress failed: %v", err)
+			}
+			if !bytes.Equal(decompressed, payload) {
+				t.Fatalf("Decompressed data does not match original data")
+			}
+		})
+	}
+}
+
+func TestCompressOptionsRatio(t *testing.T) {
+	payload := bytes.Repeat([]byte("compression ratio sample payload 0123456789 "), 4096)
+
+	fast, err := NewCompress(TypeBrotli, WithFastest()).Compress(payload)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+	best, err := NewCompress(TypeBrotli, WithBestCompression()).Compress(payload)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+	if len(best) > len(fast) {
+		t.Errorf("Expected best compression (%d bytes) to be no larger than fastest (%d bytes)", len(best), len(fast))
+	}
+}
+
+func TestCompressOptionsInvalidLevel(t *testing.T) {
+	for _, typ := range []TypeStr{TypeGzip, TypeZlib, TypeLz4, TypeBrotli} {
+		if _, err := NewCompress(typ, WithLevel(42)).Compress([]byte("test")); err == nil {
+			t.Errorf("Expected error for invalid %s level", typ)
+		}
+	}
+}
+
+func TestCompressWithDictionary(t *testing.T) {
+	samples := make([][]byte, 100)
+	for i := range samples {
+		samples[i] = []byte(`{"event":"user.login","user":"user-` + string(rune('a'+i%26)) + `","ok":true}`)
+	}
+
+	dict, err := zstd.TrainDict(samples, 0, 4<<10)
+	if err != nil {
+		t.Fatalf("TrainDict failed: %v", err)
+	}
+
+	c := NewCompress(TypeZstd, WithDictionary(dict))
+	compressed, err := c.Compress(samples[0])
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+
+	decompressed, err := c.Decompress(compressed)
+	if err != nil {
+		t.Fatalf("Decompress failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, samples[0]) {
+		t.Fatalf("Expected %q, got %q", samples[0], decompressed)
+	}
+}
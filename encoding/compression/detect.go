@@ -0,0 +1,99 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/inovacc/utils/v2/encoding/compression/brotli"
+)
+
+// ErrUnknownFormat is returned when the compression format cannot be detected.
+var ErrUnknownFormat = errors.New("unknown compression format")
+
+var (
+	magicZstd         = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicGzip         = []byte{0x1f, 0x8b}
+	magicLz4          = []byte{0x04, 0x22, 0x4d, 0x18}
+	magicZip          = []byte{0x50, 0x4b, 0x03, 0x04}
+	magicZipEmpty     = []byte{0x50, 0x4b, 0x05, 0x06}
+	magicSnappyFramed = []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+)
+
+// brotliProbeSize bounds how much output is decoded when probing for brotli.
+const brotliProbeSize = 512
+
+// Detect inspects the leading bytes of data and returns the compression type
+// that produced it. Zstd, gzip, lz4 frames, zip and framed snappy streams are
+// identified by their magic numbers and zlib by its header checksum. Brotli has
+// no magic number, so it is detected last by trial-decoding a short prefix;
+// arbitrary data occasionally passes that probe, so a brotli result is a best guess.
+// Snappy block data (as produced by Compress) carries no header and is not detected.
+func Detect(data []byte) (TypeStr, error) {
+	switch {
+	case bytes.HasPrefix(data, magicZstd):
+		return TypeZstd, nil
+	case bytes.HasPrefix(data, magicGzip):
+		return TypeGzip, nil
+	case bytes.HasPrefix(data, magicLz4):
+		return TypeLz4, nil
+	case bytes.HasPrefix(data, magicZip), bytes.HasPrefix(data, magicZipEmpty):
+		return TypeZip, nil
+	case bytes.HasPrefix(data, magicSnappyFramed):
+		return TypeSnappy, nil
+	case isZlib(data):
+		return TypeZlib, nil
+	case isBrotli(data):
+		return TypeBrotli, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// DecompressAny detects the compression type of data and decompresses it.
+// Options such as WithMaxSize are applied to the detected algorithm.
+func DecompressAny(data []byte, opts ...Option) ([]byte, error) {
+	t, err := Detect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCompress(t, opts...)
+	if t != TypeSnappy {
+		return c.Decompress(data)
+	}
+
+	// Detected snappy data is always framed, which only the stream reader understands.
+	r, err := c.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+	return io.ReadAll(r)
+}
+
+// isZlib reports whether data starts with a valid RFC 1950 header using deflate.
+func isZlib(data []byte) bool {
+	if len(data) < 2 {
+		return false
+	}
+	cmf, flg := data[0], data[1]
+	return cmf&0x0f == 8 && cmf>>4 <= 7 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
+
+// isBrotli reports whether a short prefix of data decodes as a brotli stream.
+func isBrotli(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	r, err := brotli.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	// A valid stream either ends cleanly or yields a full probe; a truncated
+	// or malformed one surfaces as an error.
+	_, err = io.ReadAll(io.LimitReader(r, brotliProbeSize))
+	return err == nil
+}
@@ -0,0 +1,79 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	payload := bytes.Repeat([]byte("detect me "), 100)
+
+	for _, typ := range []TypeStr{TypeZstd, TypeGzip, TypeLz4, TypeBrotli, TypeZlib, TypeZip} {
+		t.Run(string(typ), func(t *testing.T) {
+			data, err := NewCompress(typ).Compress(payload)
+			if err != nil {
+				t.Fatalf("Compress failed: %v", err)
+			}
+
+			got, err := Detect(data)
+			if err != nil {
+				t.Fatalf("Detect failed: %v", err)
+			}
+			if got != typ {
+				t.Fatalf("Expected %s, got %s", typ, got)
+			}
+
+			decompressed, err := DecompressAny(data)
+			if err != nil {
+				t.Fatalf("DecompressAny failed: %v", err)
+			}
+			if !bytes.Equal(decompressed, payload) {
+				t.Fatalf("Decompressed data does not match original data")
+			}
+		})
+	}
+}
+
+func TestDetectSnappyFramed(t *testing.T) {
+	payload := []byte("framed snappy payload")
+
+	var buf bytes.Buffer
+	w, err := NewCompress(TypeSnappy).NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	got, err := Detect(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if got != TypeSnappy {
+		t.Fatalf("Expected %s, got %s", TypeSnappy, got)
+	}
+
+	decompressed, err := DecompressAny(buf.Bytes())
+	if err != nil {
+		t.Fatalf("DecompressAny failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, payload) {
+		t.Fatalf("Expected %q, got %q", payload, decompressed)
+	}
+}
+
+func TestDetectUnknown(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("hello world, this is plain text"), {0x00, 0x01, 0x02}} {
+		if _, err := Detect(data); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("Expected ErrUnknownFormat for %q, got %v", data, err)
+		}
+		if _, err := DecompressAny(data); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("Expected ErrUnknownFormat from DecompressAny for %q, got %v", data, err)
+		}
+	}
+}
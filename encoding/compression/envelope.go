@@ -0,0 +1,108 @@
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+var (
+	// ErrInvalidEnvelope is returned when data is not a well-formed envelope.
+	ErrInvalidEnvelope = errors.New("invalid compression envelope")
+	// ErrChecksumMismatch is returned when the opened content does not match the
+	// length or checksum recorded in the envelope.
+	ErrChecksumMismatch = errors.New("compression envelope checksum mismatch")
+)
+
+// envelopeMagic starts every envelope.
+//
+// Layout (all integers big-endian):
+//
+//	magic     [4]byte  "UENV"
+//	version   uint8
+//	typeLen   uint8
+//	type      [typeLen]byte
+//	size      uint64   original length
+//	checksum  uint32   CRC32C (Castagnoli) of the original data
+//	payload   compressed data
+var envelopeMagic = []byte("UENV")
+
+const envelopeVersion = 1
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Seal compresses data and wraps it in a self-describing envelope recording the
+// algorithm, the original length and a CRC32C checksum, so it can be restored
+// with Open without storing the type separately.
+func (c *Compress) Seal(data []byte) ([]byte, error) {
+	if len(c.Type) > 255 {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, c.Type)
+	}
+	payload, err := c.Compress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(envelopeMagic)+2+len(c.Type)+12+len(payload))
+	out = append(out, envelopeMagic...)
+	out = append(out, envelopeVersion, byte(len(c.Type)))
+	out = append(out, c.Type...)
+	out = binary.BigEndian.AppendUint64(out, uint64(len(data)))
+	out = binary.BigEndian.AppendUint32(out, crc32.Checksum(data, castagnoli))
+	return append(out, payload...), nil
+}
+
+// Open restores data sealed with Seal, verifying its length and checksum.
+// Options are applied to the algorithm recorded in the envelope; output is never
+// inflated past the recorded length, and WithMaxSize and WithMaxRatio still apply.
+func Open(envelope []byte, opts ...Option) ([]byte, error) {
+	t, size, sum, payload, err := parseEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCompress(t, opts...)
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := decompressChecked(codec, payload, func(n int64) error {
+		if uint64(n) > size {
+			return ErrChecksumMismatch
+		}
+		return c.checkOutput(n, len(payload))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(data)) != size || crc32.Checksum(data, castagnoli) != sum {
+		return nil, ErrChecksumMismatch
+	}
+	return data, nil
+}
+
+// IsEnvelope reports whether data starts with the envelope magic.
+func IsEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, envelopeMagic)
+}
+
+func parseEnvelope(envelope []byte) (TypeStr, uint64, uint32, []byte, error) {
+	rest, ok := bytes.CutPrefix(envelope, envelopeMagic)
+	if !ok || len(rest) < 2 || rest[0] != envelopeVersion {
+		return "", 0, 0, nil, ErrInvalidEnvelope
+	}
+
+	typeLen := int(rest[1])
+	rest = rest[2:]
+	if len(rest) < typeLen+12 {
+		return "", 0, 0, nil, ErrInvalidEnvelope
+	}
+
+	t := TypeStr(rest[:typeLen])
+	rest = rest[typeLen:]
+	return t, binary.BigEndian.Uint64(rest[0:8]), binary.BigEndian.Uint32(rest[8:12]), rest[12:], nil
+}
@@ -0,0 +1,66 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	payload := bytes.Repeat([]byte("sealed payload "), 1000)
+
+	for _, typ := range builtinTypes {
+		t.Run(string(typ), func(t *testing.T) {
+			sealed, err := NewCompress(typ).Seal(payload)
+			if err != nil {
+				t.Fatalf("Seal failed: %v", err)
+			}
+			if !IsEnvelope(sealed) {
+				t.Fatalf("Expected sealed data to be an envelope")
+			}
+
+			opened, err := Open(sealed)
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			if !bytes.Equal(opened, payload) {
+				t.Fatalf("Opened data does not match original data")
+			}
+		})
+	}
+}
+
+func TestOpenCorrupt(t *testing.T) {
+	sealed, err := NewCompress(TypeSnappy).Seal([]byte("hello envelope, hello envelope"))
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+
+	corrupt := bytes.Clone(sealed)
+	corrupt[len(corrupt)-1] ^= 0xff
+	if _, err := Open(corrupt); err == nil {
+		t.Errorf("Expected error for corrupted payload")
+	}
+
+	badSum := bytes.Clone(sealed)
+	badSum[len(envelopeMagic)+2+len(TypeSnappy)+8] ^= 0xff
+	if _, err := Open(badSum); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected ErrChecksumMismatch, got %v", err)
+	}
+
+	for _, data := range [][]byte{nil, []byte("UENV"), sealed[:10], []byte("plain data")} {
+		if _, err := Open(data); !errors.Is(err, ErrInvalidEnvelope) {
+			t.Errorf("Expected ErrInvalidEnvelope for %q, got %v", data, err)
+		}
+	}
+}
+
+func TestOpenLimits(t *testing.T) {
+	sealed, err := NewCompress(TypeZstd).Seal(make([]byte, 2<<20))
+	if err != nil {
+		t.Fatalf("Seal failed: %v", err)
+	}
+	if _, err := Open(sealed, WithMaxSize(1<<20)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// evaluateRounds is how many times each codec is timed; the fastest run is kept.
+const evaluateRounds = 3
+
+// Result holds the measurements of one algorithm on a sample.
+type Result struct {
+	Type           TypeStr
+	OriginalSize   int
+	CompressedSize int
+	// Ratio is OriginalSize divided by CompressedSize; higher is better.
+	Ratio float64
+	// CompressSpeed and DecompressSpeed are in uncompressed bytes per second.
+	CompressSpeed   float64
+	DecompressSpeed float64
+}
+
+// Report holds the results of Evaluate for every built-in algorithm.
+type Report struct {
+	Results []Result
+}
+
+// Evaluate compresses and decompresses sample with every built-in algorithm,
+// measuring compression ratio and throughput. Options such as WithLevel are
+// applied to each algorithm. Use Report.Recommend to pick the best fit.
+func Evaluate(sample []byte, opts ...Option) (*Report, error) {
+	if len(sample) == 0 {
+		return nil, errors.New("sample cannot be empty")
+	}
+
+	report := &Report{Results: make([]Result, 0, len(builtinTypes))}
+	for _, typ := range builtinTypes {
+		res, err := evaluate(NewCompress(typ, opts...), sample)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typ, err)
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+func evaluate(c *Compress, sample []byte) (Result, error) {
+	var compressed []byte
+	compressTime, err := fastest(func() (err error) {
+		compressed, err = c.Compress(sample)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	var decompressed []byte
+	decompressTime, err := fastest(func() (err error) {
+		decompressed, err = c.Decompress(compressed)
+		return err
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if !bytes.Equal(decompressed, sample) {
+		return Result{}, errors.New("round trip mismatch")
+	}
+
+	return Result{
+		Type:            c.Type,
+		OriginalSize:    len(sample),
+		CompressedSize:  len(compressed),
+		Ratio:           float64(len(sample)) / float64(max(len(compressed), 1)),
+		CompressSpeed:   float64(len(sample)) / compressTime.Seconds(),
+		DecompressSpeed: float64(len(sample)) / decompressTime.Seconds(),
+	}, nil
+}
+
+// fastest runs fn evaluateRounds times and returns the shortest duration.
+func fastest(fn func() error) (time.Duration, error) {
+	best := time.Duration(math.MaxInt64)
+	for range evaluateRounds {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return 0, err
+		}
+		best = min(best, max(time.Since(start), time.Nanosecond))
+	}
+	return best, nil
+}
+
+// Recommend returns the algorithm with the best weighted score. speedWeight
+// ranges from 0 (only compression ratio matters) to 1 (only throughput matters);
+// values outside that range are clamped. Each metric is scored relative to the
+// best result, and throughput is the geometric mean of compress and decompress speed.
+func (r *Report) Recommend(speedWeight float64) TypeStr {
+	speedWeight = min(max(speedWeight, 0), 1)
+
+	var bestRatio, bestSpeed float64
+	for _, res := range r.Results {
+		bestRatio = max(bestRatio, res.Ratio)
+		bestSpeed = max(bestSpeed, res.speed())
+	}
+
+	var (
+		best      TypeStr
+		bestScore = -1.0
+	)
+	for _, res := range r.Results {
+		score := (1-speedWeight)*res.Ratio/bestRatio + speedWeight*res.speed()/bestSpeed
+		if score > bestScore {
+			best, bestScore = res.Type, score
+		}
+	}
+	return best
+}
+
+func (res Result) speed() float64 {
+	return math.Sqrt(res.CompressSpeed * res.DecompressSpeed)
+}
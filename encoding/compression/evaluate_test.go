@@ -0,0 +1,49 @@
+package compression
+
+import (
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	sample := chunkedPayload()[:64<<10]
+
+	report, err := Evaluate(sample)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if len(report.Results) != 7 {
+		t.Fatalf("Expected 7 results, got %d", len(report.Results))
+	}
+
+	for _, res := range report.Results {
+		if res.OriginalSize != len(sample) {
+			t.Errorf("%s: expected original size %d, got %d", res.Type, len(sample), res.OriginalSize)
+		}
+		if res.Ratio <= 1 {
+			t.Errorf("%s: expected ratio above 1 on repetitive data, got %f", res.Type, res.Ratio)
+		}
+		if res.CompressSpeed <= 0 || res.DecompressSpeed <= 0 {
+			t.Errorf("%s: expected positive throughput, got %f/%f", res.Type, res.CompressSpeed, res.DecompressSpeed)
+		}
+	}
+
+	smallest := report.Results[0]
+	for _, res := range report.Results[1:] {
+		if res.Ratio > smallest.Ratio {
+			smallest = res
+		}
+	}
+	if got := report.Recommend(0); got != smallest.Type {
+		t.Errorf("Expected size-only recommendation %s, got %s", smallest.Type, got)
+	}
+
+	if got := report.Recommend(0.5); got == "" {
+		t.Errorf("Expected a balanced recommendation")
+	}
+}
+
+func TestEvaluateEmpty(t *testing.T) {
+	if _, err := Evaluate(nil); err == nil {
+		t.Errorf("Expected error for empty sample")
+	}
+}
@@ -1,31 +1,58 @@
 package gzip
 
 import (
-	"bytes"
 	"compress/gzip"
 	"io"
 )
 
-func Compress(data []byte) ([]byte, error) {
-	var b bytes.Buffer
-	w := gzip.NewWriter(&b)
-	_, err := w.Write(data)
-	if err != nil {
-		return nil, err
+// Option configures the gzip writer used by Compress and NewWriter.
+type Option func(*options)
+
+type options struct {
+	level int
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{level: gzip.DefaultCompression}
+	for _, opt := range opts {
+		opt(o)
 	}
-	if err := w.Close(); err != nil {
-		return nil, err
+	return o
+}
+
+// WithLevel sets the compression level, from gzip.HuffmanOnly (-2) and
+// gzip.BestSpeed (1) up to gzip.BestCompression (9).
+func WithLevel(level int) Option {
+	return func(o *options) {
+		o.level = level
 	}
-	return b.Bytes(), nil
 }
 
+// Compress compresses data, reusing pooled writers between calls.
+func Compress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendCompress(nil, data, opts...)
+}
+
+// Decompress decompresses data, reusing pooled readers between calls.
 func Decompress(data []byte) ([]byte, error) {
-	r, err := gzip.NewReader(bytes.NewReader(data))
+	return AppendDecompress(nil, data)
+}
+
+// NewWriter returns a gzip writer that compresses everything written to it into w.
+// The caller must Close the writer to flush the trailer.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	zw, err := gzip.NewWriterLevel(w, newOptions(opts).level)
+	if err != nil {
+		return nil, err
+	}
+	return zw, nil
+}
+
+// NewReader returns a gzip reader that decompresses the stream read from r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	zr, err := gzip.NewReader(r)
 	if err != nil {
 		return nil, err
 	}
-	defer func(r *gzip.Reader) {
-		_ = r.Close()
-	}(r)
-	return io.ReadAll(r)
+	return zr, nil
 }
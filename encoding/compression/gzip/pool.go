@@ -0,0 +1,92 @@
+package gzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Writers keep their level across Reset, so there is one pool per level.
+var (
+	writerPools [gzip.BestCompression - gzip.HuffmanOnly + 1]sync.Pool
+	readerPool  sync.Pool
+)
+
+// emptyStream is a complete gzip stream with no data. A gzip.Reader can only be
+// reset onto a valid header, so pooled readers are parked on this one to release
+// the caller's input.
+var emptyStream = func() []byte {
+	var buf bytes.Buffer
+	_ = gzip.NewWriter(&buf).Close()
+	return buf.Bytes()
+}()
+
+func getWriter(w io.Writer, level int) (*gzip.Writer, error) {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		return nil, fmt.Errorf("gzip: invalid compression level: %d", level)
+	}
+	if zw, ok := writerPools[level-gzip.HuffmanOnly].Get().(*gzip.Writer); ok {
+		zw.Reset(w)
+		return zw, nil
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+func putWriter(zw *gzip.Writer, level int) {
+	zw.Reset(io.Discard)
+	writerPools[level-gzip.HuffmanOnly].Put(zw)
+}
+
+func getReader(r io.Reader) (*gzip.Reader, error) {
+	if zr, ok := readerPool.Get().(*gzip.Reader); ok {
+		if err := zr.Reset(r); err != nil {
+			return nil, err
+		}
+		return zr, nil
+	}
+	return gzip.NewReader(r)
+}
+
+func putReader(zr *gzip.Reader) {
+	if zr.Reset(bytes.NewReader(emptyStream)) == nil {
+		readerPool.Put(zr)
+	}
+}
+
+// AppendCompress appends the gzip stream of src to dst and returns the extended slice.
+func AppendCompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	level := newOptions(opts).level
+	buf := bytes.NewBuffer(dst)
+	zw, err := getWriter(buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	putWriter(zw, level)
+	return buf.Bytes(), nil
+}
+
+// AppendDecompress appends the decompressed contents of src to dst and returns
+// the extended slice.
+func AppendDecompress(dst, src []byte) ([]byte, error) {
+	zr, err := getReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(dst)
+	if _, err := buf.ReadFrom(zr); err != nil {
+		return nil, err
+	}
+	if err := zr.Close(); err != nil {
+		return nil, err
+	}
+	putReader(zr)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,160 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrLimitExceeded matches every *LimitError via errors.Is.
+var ErrLimitExceeded = errors.New("decompression limit exceeded")
+
+// ratioGrace is the output allowed before the ratio ceiling applies to streams,
+// since headers make the ratio meaningless for the first few bytes.
+const ratioGrace = 64 << 10
+
+// LimitError is returned when decompressed output exceeds the size cap set by
+// WithMaxSize or the compression-ratio ceiling set by WithMaxRatio.
+type LimitError struct {
+	// MaxSize is the output cap in bytes, or 0 if the ratio ceiling was hit.
+	MaxSize int64
+	// MaxRatio is the ratio ceiling, or 0 if the size cap was hit.
+	MaxRatio float64
+}
+
+func (e *LimitError) Error() string {
+	if e.MaxRatio > 0 {
+		return fmt.Sprintf("decompressed output exceeds compression ratio of %g", e.MaxRatio)
+	}
+	return fmt.Sprintf("decompressed output exceeds %d bytes", e.MaxSize)
+}
+
+// Is reports whether target is ErrLimitExceeded.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// WithMaxSize caps the decompressed output at n bytes. Decompression stops as
+// soon as the cap is crossed and returns a *LimitError.
+func WithMaxSize(n int64) Option {
+	return func(c *Compress) {
+		c.maxSize = n
+	}
+}
+
+// WithMaxRatio caps the decompressed output at ratio times the compressed input
+// and returns a *LimitError when crossed. Streams are allowed a small grace
+// amount of output before the ceiling is enforced.
+func WithMaxRatio(ratio float64) Option {
+	return func(c *Compress) {
+		c.maxRatio = ratio
+	}
+}
+
+func (c *Compress) limited() bool {
+	return c.maxSize > 0 || c.maxRatio > 0
+}
+
+// opener is implemented by codecs that can stream the content of a whole
+// compressed buffer, letting limits stop decompression early.
+type opener interface {
+	Open(data []byte) (io.ReadCloser, error)
+}
+
+// sizer is implemented by codecs whose data records its decompressed length.
+type sizer interface {
+	DecodedLen(data []byte) (int, error)
+}
+
+// checkOutput reports whether n bytes of output decompressed from inputLen
+// bytes exceed the size cap or ratio ceiling.
+func (c *Compress) checkOutput(n int64, inputLen int) error {
+	if c.maxSize > 0 && n > c.maxSize {
+		return &LimitError{MaxSize: c.maxSize}
+	}
+	if c.maxRatio > 0 && float64(n) > c.maxRatio*float64(inputLen) {
+		return &LimitError{MaxRatio: c.maxRatio}
+	}
+	return nil
+}
+
+// decompressChecked decompresses data, calling check with the output size as
+// it grows so oversized output is rejected before it is fully inflated
+// whenever the codec allows it.
+func decompressChecked(codec Codec, data []byte, check func(n int64) error) ([]byte, error) {
+	switch cd := codec.(type) {
+	case sizer:
+		n, err := cd.DecodedLen(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(int64(n)); err != nil {
+			return nil, err
+		}
+		return codec.Decompress(data)
+	case opener:
+		rc, err := cd.Open(data)
+		if err != nil {
+			return nil, err
+		}
+		defer func(rc io.ReadCloser) {
+			_ = rc.Close()
+		}(rc)
+
+		var buf bytes.Buffer
+		for {
+			_, err := io.CopyN(&buf, rc, 32<<10)
+			if err := check(int64(buf.Len())); err != nil {
+				return nil, err
+			}
+			if errors.Is(err, io.EOF) {
+				return buf.Bytes(), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		out, err := codec.Decompress(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(int64(len(out))); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+// countingReader counts the compressed bytes consumed by a stream decoder.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// limitedReader enforces the size cap and ratio ceiling on a decompressing stream.
+type limitedReader struct {
+	io.ReadCloser
+	c   *Compress
+	in  *countingReader
+	out int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	n, err := lr.ReadCloser.Read(p)
+	lr.out += int64(n)
+	if lr.c.maxSize > 0 && lr.out > lr.c.maxSize {
+		return n, &LimitError{MaxSize: lr.c.maxSize}
+	}
+	if lr.c.maxRatio > 0 && lr.out > ratioGrace && float64(lr.out) > lr.c.maxRatio*float64(lr.in.n) {
+		return n, &LimitError{MaxRatio: lr.c.maxRatio}
+	}
+	return n, err
+}
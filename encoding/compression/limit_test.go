@@ -0,0 +1,93 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecompressLimits(t *testing.T) {
+	bomb := make([]byte, 8<<20)
+
+	for _, typ := range builtinTypes {
+		t.Run(string(typ), func(t *testing.T) {
+			compressed, err := NewCompress(typ).Compress(bomb)
+			if err != nil {
+				t.Fatalf("Compress failed: %v", err)
+			}
+
+			_, err = NewCompress(typ, WithMaxSize(1<<20)).Decompress(compressed)
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.MaxSize != 1<<20 {
+				t.Fatalf("Expected size LimitError, got %v", err)
+			}
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Fatalf("Expected errors.Is ErrLimitExceeded, got %v", err)
+			}
+
+			_, err = NewCompress(typ, WithMaxRatio(10)).Decompress(compressed)
+			if !errors.As(err, &limitErr) || limitErr.MaxRatio != 10 {
+				t.Fatalf("Expected ratio LimitError, got %v", err)
+			}
+
+			out, err := NewCompress(typ, WithMaxSize(int64(len(bomb)))).Decompress(compressed)
+			if err != nil {
+				t.Fatalf("Decompress within limit failed: %v", err)
+			}
+			if len(out) != len(bomb) {
+				t.Fatalf("Expected %d bytes, got %d", len(bomb), len(out))
+			}
+		})
+	}
+}
+
+func TestNewReaderLimits(t *testing.T) {
+	bomb := make([]byte, 8<<20)
+	compressed, err := NewCompress(TypeGzip).Compress(bomb)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+
+	for _, opt := range []Option{WithMaxSize(1 << 20), WithMaxRatio(50)} {
+		r, err := NewCompress(TypeGzip, opt).NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			t.Fatalf("NewReader failed: %v", err)
+		}
+		if _, err := io.Copy(io.Discard, r); !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("Expected ErrLimitExceeded, got %v", err)
+		}
+	}
+}
+
+func TestLimitsChunkedAndAny(t *testing.T) {
+	bomb := make([]byte, 4<<20)
+
+	chunked, err := NewCompress(TypeZstd).CompressChunked(bomb, 0)
+	if err != nil {
+		t.Fatalf("CompressChunked failed: %v", err)
+	}
+	if _, err := DecompressChunked(chunked, WithMaxSize(1<<20)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded from DecompressChunked, got %v", err)
+	}
+
+	compressed, err := NewCompress(TypeZstd).Compress(bomb)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+	if _, err := DecompressAny(compressed, WithMaxRatio(100)); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded from DecompressAny, got %v", err)
+	}
+}
+
+func TestLimitsRegisteredCodec(t *testing.T) {
+	Register("reverse-limited", reverseCodec{})
+
+	c := NewCompress("reverse-limited", WithMaxSize(3))
+	if _, err := c.Decompress([]byte("abcd")); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected ErrLimitExceeded, got %v", err)
+	}
+	if _, err := c.Decompress([]byte("abc")); err != nil {
+		t.Errorf("Decompress within limit failed: %v", err)
+	}
+}
@@ -1,26 +1,79 @@
 package lz4
 
 import (
-	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/pierrec/lz4/v4"
 )
 
-func Compress(data []byte) ([]byte, error) {
-	var b bytes.Buffer
-	w := lz4.NewWriter(&b)
-	_, err := w.Write(data)
-	if err != nil {
-		return nil, err
+// Option configures the lz4 writer used by Compress and NewWriter.
+type Option func(*options)
+
+type options struct {
+	writer []lz4.Option
+	err    error
+}
+
+// WithLevel sets the compression level, from 0 (fast, the default) up to 9.
+func WithLevel(level int) Option {
+	return func(o *options) {
+		switch {
+		case level == 0:
+			o.writer = append(o.writer, lz4.CompressionLevelOption(lz4.Fast))
+		case level >= 1 && level <= 9:
+			o.writer = append(o.writer, lz4.CompressionLevelOption(lz4.CompressionLevel(1<<(8+level))))
+		default:
+			o.err = fmt.Errorf("lz4: invalid compression level %d", level)
+		}
 	}
-	if err := w.Close(); err != nil {
-		return nil, err
+}
+
+// WithBlockSize sets the maximum size of compressed blocks in bytes.
+// Valid sizes are 64KB, 256KB, 1MB and 4MB (the default).
+func WithBlockSize(size int) Option {
+	return func(o *options) {
+		o.writer = append(o.writer, lz4.BlockSizeOption(lz4.BlockSize(size)))
+	}
+}
+
+// WithConcurrency sets the number of goroutines used to compress blocks.
+// A value of 0 or less uses GOMAXPROCS.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.writer = append(o.writer, lz4.ConcurrencyOption(n))
 	}
-	return b.Bytes(), nil
 }
 
+// Compress compresses data into an lz4 frame. Calls without options reuse
+// pooled writers between calls.
+func Compress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendCompress(nil, data, opts...)
+}
+
+// Decompress decompresses an lz4 frame, reusing pooled readers between calls.
 func Decompress(data []byte) ([]byte, error) {
-	r := lz4.NewReader(bytes.NewReader(data))
-	return io.ReadAll(r)
+	return AppendDecompress(nil, data)
+}
+
+// NewWriter returns an lz4 frame writer that compresses everything written to it into w.
+// The caller must Close the writer to flush the end mark.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.err != nil {
+		return nil, o.err
+	}
+	zw := lz4.NewWriter(w)
+	if err := zw.Apply(o.writer...); err != nil {
+		return nil, err
+	}
+	return zw, nil
+}
+
+// NewReader returns an lz4 frame reader that decompresses the stream read from r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(lz4.NewReader(r)), nil
 }
@@ -2,6 +2,7 @@ package lz4
 
 import (
 	"bytes"
+	"io"
 	"testing"
 
 	"github.com/inovacc/utils/v2/encoding/encoder"
@@ -45,3 +46,125 @@ func TestDecompress(t *testing.T) {
 		return
 	}
 }
+
+func TestNewWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Errorf("NewWriter failed: %v", err)
+		return
+	}
+
+	payload := bytes.Repeat([]byte("test"), 1024)
+	if _, err := w.Write(payload); err != nil {
+		t.Errorf("Write failed: %v", err)
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+		return
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Errorf("NewReader failed: %v", err)
+		return
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		t.Errorf("ReadAll failed: %v", err)
+		return
+	}
+
+	if !bytes.Equal(decompressed, payload) {
+		t.Errorf("Decompressed data does not match original data")
+		return
+	}
+}
+
+func TestCompressWithOptions(t *testing.T) {
+	payload := bytes.Repeat([]byte("test"), 1024)
+	data, err := Compress(payload, WithLevel(9))
+	if err != nil {
+		t.Errorf("Compress failed: %v", err)
+		return
+	}
+
+	decompressed, err := Decompress(data)
+	if err != nil {
+		t.Errorf("Decompress failed: %v", err)
+		return
+	}
+
+	if !bytes.Equal(decompressed, payload) {
+		t.Errorf("Decompressed data does not match original data")
+		return
+	}
+
+	if _, err := Compress(payload, WithLevel(-1)); err == nil {
+		t.Errorf("Expected error for invalid level")
+		return
+	}
+}
+
+func TestAppendCompress(t *testing.T) {
+	prefix := []byte("prefix:")
+	payload := bytes.Repeat([]byte("test"), 1024)
+
+	out, err := AppendCompress(append([]byte(nil), prefix...), payload)
+	if err != nil {
+		t.Errorf("AppendCompress failed: %v", err)
+		return
+	}
+
+	if !bytes.HasPrefix(out, prefix) {
+		t.Errorf("AppendCompress did not preserve dst")
+		return
+	}
+
+	decompressed, err := AppendDecompress(append([]byte(nil), prefix...), out[len(prefix):])
+	if err != nil {
+		t.Errorf("AppendDecompress failed: %v", err)
+		return
+	}
+
+	if !bytes.Equal(decompressed, append(prefix, payload...)) {
+		t.Errorf("Decompressed data does not match original data")
+		return
+	}
+}
+
+func BenchmarkCompressNewWriter(b *testing.B) {
+	payload := bytes.Repeat([]byte("benchmark payload "), 512)
+	b.ReportAllocs()
+	for b.Loop() {
+		var buf bytes.Buffer
+		w, _ := NewWriter(&buf)
+		_, _ = w.Write(payload)
+		_ = w.Close()
+	}
+}
+
+func BenchmarkAppendCompress(b *testing.B) {
+	payload := bytes.Repeat([]byte("benchmark payload "), 512)
+	var dst []byte
+	b.ReportAllocs()
+	for b.Loop() {
+		dst, _ = AppendCompress(dst[:0], payload)
+	}
+}
+
+func BenchmarkAppendDecompress(b *testing.B) {
+	payload := bytes.Repeat([]byte("benchmark payload "), 512)
+	compressed, _ := Compress(payload)
+	var dst []byte
+	b.ReportAllocs()
+	for b.Loop() {
+		dst, _ = AppendDecompress(dst[:0], compressed)
+	}
+}
@@ -0,0 +1,68 @@
+package lz4
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// Only default-configured writers are pooled, since options cannot be compared.
+var (
+	writerPool sync.Pool
+	readerPool sync.Pool
+)
+
+// AppendCompress appends the lz4 frame of src to dst and returns the extended slice.
+func AppendCompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	if len(opts) > 0 {
+		w, err := NewWriter(buf, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(src); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	zw, ok := writerPool.Get().(*lz4.Writer)
+	if ok {
+		zw.Reset(buf)
+	} else {
+		zw = lz4.NewWriter(buf)
+	}
+	if _, err := zw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	zw.Reset(io.Discard)
+	writerPool.Put(zw)
+	return buf.Bytes(), nil
+}
+
+// AppendDecompress appends the decompressed contents of src to dst and returns
+// the extended slice.
+func AppendDecompress(dst, src []byte) ([]byte, error) {
+	r := bytes.NewReader(src)
+	zr, ok := readerPool.Get().(*lz4.Reader)
+	if ok {
+		zr.Reset(r)
+	} else {
+		zr = lz4.NewReader(r)
+	}
+	buf := bytes.NewBuffer(dst)
+	if _, err := buf.ReadFrom(zr); err != nil {
+		return nil, err
+	}
+	zr.Reset(nil)
+	readerPool.Put(zr)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,155 @@
+package compression
+
+import (
+	"github.com/inovacc/utils/v2/encoding/compression/brotli"
+	"github.com/inovacc/utils/v2/encoding/compression/gzip"
+	"github.com/inovacc/utils/v2/encoding/compression/lz4"
+	"github.com/inovacc/utils/v2/encoding/compression/zip"
+	"github.com/inovacc/utils/v2/encoding/compression/zlib"
+	"github.com/inovacc/utils/v2/encoding/compression/zstd"
+)
+
+// Option represents a modification to the default tuning of a Compress.
+// Options that do not apply to the selected algorithm are ignored.
+type Option func(*Compress)
+
+// preset selects an algorithm-appropriate level when no explicit level is set.
+type preset int
+
+const (
+	presetNone preset = iota
+	presetFastest
+	presetBest
+)
+
+// WithLevel sets the compression level using the native scale of the algorithm:
+// 1-22 for zstd, -2-9 for gzip, zlib and zip, 0-9 for lz4 and 0-11 (quality) for brotli.
+// Snappy has no levels.
+func WithLevel(level int) Option {
+	return func(c *Compress) {
+		c.level = &level
+	}
+}
+
+// WithFastest selects the fastest level of the algorithm.
+func WithFastest() Option {
+	return func(c *Compress) {
+		c.preset = presetFastest
+	}
+}
+
+// WithBestCompression selects the level with the highest compression ratio.
+func WithBestCompression() Option {
+	return func(c *Compress) {
+		c.preset = presetBest
+	}
+}
+
+// WithConcurrency sets the number of encoder goroutines for zstd and lz4.
+// A value of 0 or less uses GOMAXPROCS.
+func WithConcurrency(n int) Option {
+	return func(c *Compress) {
+		c.concurrency = &n
+	}
+}
+
+// WithWindow sets the base 2 logarithm of the window size for zstd and brotli
+// (brotli's lgwin).
+func WithWindow(log int) Option {
+	return func(c *Compress) {
+		c.window = log
+	}
+}
+
+// WithBlockSize sets the lz4 block size in bytes (64KB, 256KB, 1MB or 4MB).
+func WithBlockSize(size int) Option {
+	return func(c *Compress) {
+		c.blockSize = size
+	}
+}
+
+// WithDictionary compresses and decompresses zstd data with a dictionary built
+// by zstd.TrainDict. Other algorithms ignore it.
+func WithDictionary(dict []byte) Option {
+	return func(c *Compress) {
+		c.dict = dict
+	}
+}
+
+// levelFor resolves the explicit level or preset into the native scale of an algorithm.
+func (c *Compress) levelFor(fastest, best int) (int, bool) {
+	if c.level != nil {
+		return *c.level, true
+	}
+	switch c.preset {
+	case presetFastest:
+		return fastest, true
+	case presetBest:
+		return best, true
+	default:
+		return 0, false
+	}
+}
+
+func (c *Compress) zstdOptions() []zstd.Option {
+	var opts []zstd.Option
+	if level, ok := c.levelFor(1, 22); ok {
+		opts = append(opts, zstd.WithLevel(level))
+	}
+	if c.concurrency != nil {
+		opts = append(opts, zstd.WithConcurrency(*c.concurrency))
+	}
+	if c.window > 0 {
+		opts = append(opts, zstd.WithWindowSize(1<<c.window))
+	}
+	if c.dict != nil {
+		opts = append(opts, zstd.WithDict(c.dict))
+	}
+	return opts
+}
+
+func (c *Compress) gzipOptions() []gzip.Option {
+	if level, ok := c.levelFor(1, 9); ok {
+		return []gzip.Option{gzip.WithLevel(level)}
+	}
+	return nil
+}
+
+func (c *Compress) zlibOptions() []zlib.Option {
+	if level, ok := c.levelFor(1, 9); ok {
+		return []zlib.Option{zlib.WithLevel(level)}
+	}
+	return nil
+}
+
+func (c *Compress) zipOptions() []zip.Option {
+	if level, ok := c.levelFor(1, 9); ok {
+		return []zip.Option{zip.WithLevel(level)}
+	}
+	return nil
+}
+
+func (c *Compress) lz4Options() []lz4.Option {
+	var opts []lz4.Option
+	if level, ok := c.levelFor(0, 9); ok {
+		opts = append(opts, lz4.WithLevel(level))
+	}
+	if c.concurrency != nil {
+		opts = append(opts, lz4.WithConcurrency(*c.concurrency))
+	}
+	if c.blockSize > 0 {
+		opts = append(opts, lz4.WithBlockSize(c.blockSize))
+	}
+	return opts
+}
+
+func (c *Compress) brotliOptions() []brotli.Option {
+	var opts []brotli.Option
+	if level, ok := c.levelFor(0, 11); ok {
+		opts = append(opts, brotli.WithQuality(level))
+	}
+	if c.window > 0 {
+		opts = append(opts, brotli.WithWindow(c.window))
+	}
+	return opts
+}
@@ -1,11 +1,64 @@
 package snappy
 
-import "github.com/golang/snappy"
+import (
+	"errors"
+	"io"
+	"slices"
 
+	"github.com/golang/snappy"
+)
+
+// Compress encodes data in the snappy block format.
 func Compress(data []byte) ([]byte, error) {
-	return snappy.Encode(nil, data), nil
+	return AppendCompress(nil, data)
 }
 
+// Decompress decodes data in the snappy block format.
 func Decompress(data []byte) ([]byte, error) {
-	return snappy.Decode(nil, data)
+	return AppendDecompress(nil, data)
+}
+
+// DecodedLen returns the decompressed length recorded in snappy block data,
+// without decoding it.
+func DecodedLen(data []byte) (int, error) {
+	return snappy.DecodedLen(data)
+}
+
+// AppendCompress appends src encoded in the snappy block format to dst and
+// returns the extended slice.
+func AppendCompress(dst, src []byte) ([]byte, error) {
+	n := snappy.MaxEncodedLen(len(src))
+	if n < 0 {
+		return nil, errors.New("snappy: input too large")
+	}
+	dst = slices.Grow(dst, n)
+	encoded := snappy.Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+len(encoded)], nil
+}
+
+// AppendDecompress appends the snappy block src, decoded, to dst and returns the
+// extended slice.
+func AppendDecompress(dst, src []byte) ([]byte, error) {
+	n, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, err
+	}
+	dst = slices.Grow(dst, n)
+	decoded, err := snappy.Decode(dst[len(dst):len(dst)+n], src)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:len(dst)+len(decoded)], nil
+}
+
+// NewWriter returns a snappy writer that compresses everything written to it into w.
+// Streams use the snappy framing format, which is not interchangeable with the
+// block format produced by Compress. The caller must Close the writer to flush it.
+func NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+// NewReader returns a snappy reader that decompresses a framed stream read from r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(snappy.NewReader(r)), nil
 }
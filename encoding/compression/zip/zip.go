@@ -3,12 +3,41 @@ package zip
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
+	"encoding/binary"
 	"io"
 )
 
-func Compress(data []byte) ([]byte, error) {
+// Option configures the deflate compressor used by Compress and NewWriter.
+type Option func(*options)
+
+type options struct {
+	level int
+}
+
+// WithLevel sets the deflate level, from flate.HuffmanOnly (-2) and
+// flate.BestSpeed (1) up to flate.BestCompression (9).
+func WithLevel(level int) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+func newZipWriter(w io.Writer, opts []Option) *zip.Writer {
+	o := &options{level: flate.DefaultCompression}
+	for _, opt := range opts {
+		opt(o)
+	}
+	zw := zip.NewWriter(w)
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, o.level)
+	})
+	return zw
+}
+
+func Compress(data []byte, opts ...Option) ([]byte, error) {
 	var b bytes.Buffer
-	w := zip.NewWriter(&b)
+	w := newZipWriter(&b, opts)
 	f, err := w.Create("data")
 	if err != nil {
 		return nil, err
@@ -24,6 +53,19 @@ func Compress(data []byte) ([]byte, error) {
 }
 
 func Decompress(data []byte) ([]byte, error) {
+	rc, err := Open(data)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+	return io.ReadAll(rc)
+}
+
+// Open returns a reader for the content of the first entry of the zip archive
+// in data, located through the central directory.
+func Open(data []byte) (io.ReadCloser, error) {
 	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
 		return nil, err
@@ -31,12 +73,67 @@ func Decompress(data []byte) ([]byte, error) {
 	if len(r.File) == 0 {
 		return nil, io.ErrUnexpectedEOF
 	}
-	rc, err := r.File[0].Open()
+	return r.File[0].Open()
+}
+
+const (
+	localFileHeaderSignature = 0x04034b50
+	localFileHeaderLen       = 30
+	dataDescriptorFlag       = 0x8
+)
+
+// entryWriter streams a single "data" entry and finalizes the archive on Close.
+type entryWriter struct {
+	io.Writer
+	zw *zip.Writer
+}
+
+func (e *entryWriter) Close() error {
+	return e.zw.Close()
+}
+
+// NewWriter returns a writer that streams everything written to it into w as
+// the single "data" entry of a zip archive, matching the layout of Compress.
+// The caller must Close the writer to write the central directory.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	zw := newZipWriter(w, opts)
+	f, err := zw.Create("data")
 	if err != nil {
 		return nil, err
 	}
-	defer func(rc io.ReadCloser) {
-		_ = rc.Close()
-	}(rc)
-	return io.ReadAll(rc)
+	return &entryWriter{Writer: f, zw: zw}, nil
+}
+
+// NewReader returns a reader for the first entry of the zip archive read from r.
+// Since r is not seekable, the entry is located through its local file header
+// instead of the central directory. Stored entries are only supported when
+// their size is recorded in that header.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	var hdr [localFileHeaderLen]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(hdr[0:4]) != localFileHeaderSignature {
+		return nil, zip.ErrFormat
+	}
+
+	flags := binary.LittleEndian.Uint16(hdr[6:8])
+	method := binary.LittleEndian.Uint16(hdr[8:10])
+	size := binary.LittleEndian.Uint32(hdr[18:22])
+	skip := int64(binary.LittleEndian.Uint16(hdr[26:28])) + int64(binary.LittleEndian.Uint16(hdr[28:30]))
+	if _, err := io.CopyN(io.Discard, r, skip); err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case zip.Deflate:
+		return flate.NewReader(r), nil
+	case zip.Store:
+		if flags&dataDescriptorFlag != 0 {
+			return nil, zip.ErrFormat
+		}
+		return io.NopCloser(io.LimitReader(r, int64(size))), nil
+	default:
+		return nil, zip.ErrAlgorithm
+	}
 }
@@ -2,6 +2,7 @@ package zip
 
 import (
 	"bytes"
+	"io"
 	"testing"
 
 	"github.com/inovacc/utils/v2/encoding/encoder"
@@ -45,3 +46,43 @@ func TestDecompress(t *testing.T) {
 		return
 	}
 }
+
+func TestNewWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Errorf("NewWriter failed: %v", err)
+		return
+	}
+
+	payload := bytes.Repeat([]byte("test"), 1024)
+	if _, err := w.Write(payload); err != nil {
+		t.Errorf("Write failed: %v", err)
+		return
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+		return
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Errorf("NewReader failed: %v", err)
+		return
+	}
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		t.Errorf("ReadAll failed: %v", err)
+		return
+	}
+
+	if !bytes.Equal(decompressed, payload) {
+		t.Errorf("Decompressed data does not match original data")
+		return
+	}
+}
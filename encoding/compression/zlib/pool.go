@@ -0,0 +1,91 @@
+package zlib
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Writers keep their level across Reset, so there is one pool per level.
+var (
+	writerPools [zlib.BestCompression - zlib.HuffmanOnly + 1]sync.Pool
+	readerPool  sync.Pool
+)
+
+// emptyStream is the zlib stream of no data. Resetting a reader parses a header,
+// so pooled readers are reset onto this instead of nil.
+var emptyStream = func() []byte {
+	var buf bytes.Buffer
+	_ = zlib.NewWriter(&buf).Close()
+	return buf.Bytes()
+}()
+
+func getWriter(w io.Writer, level int) (*zlib.Writer, error) {
+	if level < zlib.HuffmanOnly || level > zlib.BestCompression {
+		return nil, fmt.Errorf("zlib: invalid compression level: %d", level)
+	}
+	if zw, ok := writerPools[level-zlib.HuffmanOnly].Get().(*zlib.Writer); ok {
+		zw.Reset(w)
+		return zw, nil
+	}
+	return zlib.NewWriterLevel(w, level)
+}
+
+func putWriter(zw *zlib.Writer, level int) {
+	zw.Reset(io.Discard)
+	writerPools[level-zlib.HuffmanOnly].Put(zw)
+}
+
+func getReader(r io.Reader) (io.ReadCloser, error) {
+	if zr, ok := readerPool.Get().(io.ReadCloser); ok {
+		if err := zr.(zlib.Resetter).Reset(r, nil); err != nil {
+			return nil, err
+		}
+		return zr, nil
+	}
+	return zlib.NewReader(r)
+}
+
+func putReader(zr io.ReadCloser) {
+	if zr.(zlib.Resetter).Reset(bytes.NewReader(emptyStream), nil) == nil {
+		readerPool.Put(zr)
+	}
+}
+
+// AppendCompress appends the zlib stream of src to dst and returns the extended slice.
+func AppendCompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	level := newOptions(opts).level
+	buf := bytes.NewBuffer(dst)
+	zw, err := getWriter(buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(src); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	putWriter(zw, level)
+	return buf.Bytes(), nil
+}
+
+// AppendDecompress appends the decompressed contents of src to dst and returns
+// the extended slice.
+func AppendDecompress(dst, src []byte) ([]byte, error) {
+	zr, err := getReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(dst)
+	if _, err := buf.ReadFrom(zr); err != nil {
+		return nil, err
+	}
+	if err := zr.Close(); err != nil {
+		return nil, err
+	}
+	putReader(zr)
+	return buf.Bytes(), nil
+}
@@ -1,31 +1,54 @@
 package zlib
 
 import (
-	"bytes"
 	"compress/zlib"
 	"io"
 )
 
-func Compress(data []byte) ([]byte, error) {
-	var b bytes.Buffer
-	w := zlib.NewWriter(&b)
-	_, err := w.Write(data)
-	if err != nil {
-		return nil, err
+// Option configures the zlib writer used by Compress and NewWriter.
+type Option func(*options)
+
+type options struct {
+	level int
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{level: zlib.DefaultCompression}
+	for _, opt := range opts {
+		opt(o)
 	}
-	if err := w.Close(); err != nil {
-		return nil, err
+	return o
+}
+
+// WithLevel sets the compression level, from zlib.HuffmanOnly (-2) and
+// zlib.BestSpeed (1) up to zlib.BestCompression (9).
+func WithLevel(level int) Option {
+	return func(o *options) {
+		o.level = level
 	}
-	return b.Bytes(), nil
 }
 
+// Compress compresses data, reusing pooled writers between calls.
+func Compress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendCompress(nil, data, opts...)
+}
+
+// Decompress decompresses data, reusing pooled readers between calls.
 func Decompress(data []byte) ([]byte, error) {
-	r, err := zlib.NewReader(bytes.NewReader(data))
+	return AppendDecompress(nil, data)
+}
+
+// NewWriter returns a zlib writer that compresses everything written to it into w.
+// The caller must Close the writer to flush the trailer.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	zw, err := zlib.NewWriterLevel(w, newOptions(opts).level)
 	if err != nil {
 		return nil, err
 	}
-	defer func(r io.ReadCloser) {
-		_ = r.Close()
-	}(r)
-	return io.ReadAll(r)
+	return zw, nil
+}
+
+// NewReader returns a zlib reader that decompresses the stream read from r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
 }
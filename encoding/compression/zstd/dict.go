@@ -0,0 +1,50 @@
+package zstd
+
+import (
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// DefaultDictSize is the dictionary size used by TrainDict when maxSize is 0.
+	DefaultDictSize = 64 << 10
+
+	// dictHashBytes is the minimum match length indexed while training.
+	dictHashBytes = 6
+)
+
+// TrainDict builds a zstd dictionary from representative sample payloads.
+// Dictionaries pay off for many small, similar messages (such as JSON records)
+// that compress poorly on their own. The dictionary is tagged with id so frames
+// can be matched to it on decompression; an id of 0 picks a random one.
+// A maxSize of 0 uses DefaultDictSize.
+func TrainDict(samples [][]byte, id uint32, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultDictSize
+	}
+	return dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: maxSize,
+		HashBytes:   dictHashBytes,
+		ZstdDictID:  id,
+	})
+}
+
+// DictID returns the ID stored in a zstd dictionary.
+func DictID(d []byte) (uint32, error) {
+	info, err := zstd.InspectDictionary(d)
+	if err != nil {
+		return 0, err
+	}
+	return info.ID(), nil
+}
+
+// WithDict compresses with the given dictionary and makes it available for
+// decompression. Pass several WithDict options to decode frames produced with
+// different dictionaries; each frame selects its dictionary by ID, while
+// compression uses the last one given.
+func WithDict(d []byte) Option {
+	return func(o *options) {
+		o.encoder = append(o.encoder, zstd.WithEncoderDict(d))
+		o.decoder = append(o.decoder, zstd.WithDecoderDicts(d))
+	}
+}
@@ -0,0 +1,60 @@
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func sampleRecords(n int) [][]byte {
+	records := make([][]byte, n)
+	for i := range records {
+		records[i] = []byte(fmt.Sprintf(
+			`{"id":%d,"type":"order.created","customer":{"name":"customer-%d","country":"BR"},"items":[{"sku":"SKU-%04d","qty":%d}],"status":"pending"}`,
+			i, i%97, i%251, i%7+1))
+	}
+	return records
+}
+
+func TestTrainDict(t *testing.T) {
+	records := sampleRecords(600)
+
+	d, err := TrainDict(records[:500], 4242, 8<<10)
+	if err != nil {
+		t.Fatalf("TrainDict failed: %v", err)
+	}
+
+	id, err := DictID(d)
+	if err != nil {
+		t.Fatalf("DictID failed: %v", err)
+	}
+	if id != 4242 {
+		t.Fatalf("Expected dictionary ID 4242, got %d", id)
+	}
+
+	record := records[550]
+	plain, err := Compress(record)
+	if err != nil {
+		t.Fatalf("Compress failed: %v", err)
+	}
+
+	withDict, err := Compress(record, WithDict(d))
+	if err != nil {
+		t.Fatalf("Compress with dictionary failed: %v", err)
+	}
+	if len(withDict) >= len(plain) {
+		t.Errorf("Expected dictionary to shrink output: %d >= %d bytes", len(withDict), len(plain))
+	}
+
+	decompressed, err := Decompress(withDict, WithDict(d))
+	if err != nil {
+		t.Fatalf("Decompress with dictionary failed: %v", err)
+	}
+	if !bytes.Equal(decompressed, record) {
+		t.Fatalf("Decompressed data does not match original data")
+	}
+
+	if _, err := Decompress(withDict); err == nil {
+		t.Errorf("Expected error decompressing without the dictionary")
+	}
+}
@@ -0,0 +1,60 @@
+package zstd
+
+import (
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// The shared encoder and decoder are safe for concurrent EncodeAll/DecodeAll
+// calls, so option-less Compress and Decompress never rebuild their state.
+var (
+	sharedEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	sharedDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil)
+	})
+)
+
+// AppendCompress appends src as a single zstd frame to dst and returns the
+// extended slice.
+func AppendCompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+	if len(o.encoder) == 0 {
+		enc, err := sharedEncoder()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(src, dst), nil
+	}
+
+	enc, err := zstd.NewWriter(nil, o.encoder...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(enc *zstd.Encoder) {
+		_ = enc.Close()
+	}(enc)
+	return enc.EncodeAll(src, dst), nil
+}
+
+// AppendDecompress appends the decompressed contents of src to dst and returns
+// the extended slice.
+func AppendDecompress(dst, src []byte, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+	if len(o.decoder) == 0 {
+		dec, err := sharedDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(src, dst)
+	}
+
+	dec, err := zstd.NewReader(nil, o.decoder...)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return dec.DecodeAll(src, dst)
+}
@@ -1,33 +1,79 @@
 package zstd
 
 import (
-	"bytes"
 	"io"
 
 	"github.com/klauspost/compress/zstd"
 )
 
-func Compress(data []byte) ([]byte, error) {
-	var b bytes.Buffer
-	w, err := zstd.NewWriter(&b)
-	if err != nil {
-		return nil, err
+// Option configures the zstd encoder and decoder used by this package.
+// Encoder-only options are ignored when decompressing.
+type Option func(*options)
+
+type options struct {
+	encoder []zstd.EOption
+	decoder []zstd.DOption
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
 	}
-	_, err = w.Write(data)
-	if err != nil {
-		return nil, err
+	return o
+}
+
+// WithLevel sets the compression level using the zstd scale (1-22).
+// Levels are mapped to the closest encoder speed supported by the implementation.
+func WithLevel(level int) Option {
+	return func(o *options) {
+		o.encoder = append(o.encoder, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+}
+
+// WithConcurrency sets the number of goroutines used by the encoder.
+// A value of 0 or less uses GOMAXPROCS.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.encoder = append(o.encoder, zstd.WithEncoderConcurrency(n))
+	}
+}
+
+// WithWindowSize sets the maximum back-reference distance in bytes.
+// It must be a power of two between 1KB and 512MB.
+func WithWindowSize(size int) Option {
+	return func(o *options) {
+		o.encoder = append(o.encoder, zstd.WithWindowSize(size))
 	}
-	if err := w.Close(); err != nil {
+}
+
+// Compress compresses data into a single zstd frame. Calls without encoder
+// options reuse a shared encoder instead of allocating a new one.
+func Compress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendCompress(nil, data, opts...)
+}
+
+// Decompress decompresses all zstd frames in data. Calls without decoder
+// options reuse a shared decoder instead of allocating a new one.
+func Decompress(data []byte, opts ...Option) ([]byte, error) {
+	return AppendDecompress(nil, data, opts...)
+}
+
+// NewWriter returns a zstd encoder that compresses everything written to it into w.
+// The caller must Close the writer to flush the final frame.
+func NewWriter(w io.Writer, opts ...Option) (io.WriteCloser, error) {
+	enc, err := zstd.NewWriter(w, newOptions(opts).encoder...)
+	if err != nil {
 		return nil, err
 	}
-	return b.Bytes(), nil
+	return enc, nil
 }
 
-func Decompress(data []byte) ([]byte, error) {
-	r, err := zstd.NewReader(bytes.NewReader(data))
+// NewReader returns a zstd decoder that decompresses the stream read from r.
+func NewReader(r io.Reader, opts ...Option) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r, newOptions(opts).decoder...)
 	if err != nil {
 		return nil, err
 	}
-	defer r.Close()
-	return io.ReadAll(r)
+	return d.IOReadCloser(), nil
 }
@@ -0,0 +1,87 @@
+package encoder
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidCharacter is returned when decoding input contains a character
+	// outside the alphabet.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrAlphabetLength is returned when an alphabet does not match the size of its base.
+	ErrAlphabetLength = errors.New("encoder: alphabet has wrong length")
+	// ErrAlphabetDuplicate is returned when an alphabet repeats a character.
+	ErrAlphabetDuplicate = errors.New("encoder: alphabet has duplicate characters")
+)
+
+var (
+	// BitcoinAlphabet is the Base58 alphabet used by Bitcoin and IPFS (the default).
+	BitcoinAlphabet = mustAlphabet(Base58, "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	// RippleAlphabet is the Base58 alphabet used by the XRP Ledger.
+	RippleAlphabet = mustAlphabet(Base58, "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+	// FlickrAlphabet is the Base58 alphabet used by Flickr short URLs.
+	FlickrAlphabet = mustAlphabet(Base58, "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
+	// Base62Alphabet is the default Base62 alphabet: digits, uppercase, lowercase.
+	Base62Alphabet = mustAlphabet(Base62, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	// Base62LowerAlphabet is the Base62 alphabet with lowercase before uppercase.
+	Base62LowerAlphabet = mustAlphabet(Base62, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+)
+
+// Alphabet maps digit values to characters for a radix encoding.
+type Alphabet struct {
+	chars     string
+	decodeMap [256]byte
+}
+
+// NewAlphabet validates chars as an alphabet for base, which must be Base58 or
+// Base62. Use it with WithAlphabet to match systems with their own digit order.
+func NewAlphabet(base BaseType, chars string) (*Alphabet, error) {
+	var size int
+	switch base {
+	case Base58:
+		size = 58
+	case Base62:
+		size = 62
+	default:
+		return nil, fmt.Errorf("encoder: custom alphabets are not supported for base type %d", base)
+	}
+
+	if len(chars) != size {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrAlphabetLength, len(chars), size)
+	}
+
+	var seen [256]bool
+	for i := 0; i < len(chars); i++ {
+		if seen[chars[i]] {
+			return nil, fmt.Errorf("%w: %q", ErrAlphabetDuplicate, chars[i])
+		}
+		seen[chars[i]] = true
+	}
+
+	return newAlphabet(chars), nil
+}
+
+func mustAlphabet(base BaseType, chars string) *Alphabet {
+	a, err := NewAlphabet(base, chars)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func newAlphabet(chars string) *Alphabet {
+	a := &Alphabet{chars: chars}
+	for i := range a.decodeMap {
+		a.decodeMap[i] = 0xFF
+	}
+	for i, c := range []byte(chars) {
+		a.decodeMap[c] = byte(i)
+	}
+	return a
+}
+
+// String returns the characters of the alphabet in digit order.
+func (a *Alphabet) String() string {
+	return a.chars
+}
@@ -0,0 +1,59 @@
+package encoder
+
+import (
+	"encoding/base32"
+	"strings"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordReplacer maps the ambiguous symbols accepted by Crockford Base32
+// onto their canonical digits and drops hyphens used for readability.
+var crockfordReplacer = strings.NewReplacer("I", "1", "L", "1", "O", "0", "-", "")
+
+type base32Encoding struct {
+	enc *base32.Encoding
+}
+
+func newBase32Encoding(enc *base32.Encoding) *base32Encoding {
+	return &base32Encoding{enc: enc}
+}
+
+func (b *base32Encoding) EncodeStr(s string) (string, error) {
+	data, err := b.Encode([]byte(s))
+	return string(data), err
+}
+
+func (b *base32Encoding) DecodeStr(s string) (string, error) {
+	data, err := b.Decode([]byte(s))
+	return string(data), err
+}
+
+func (b *base32Encoding) Encode(data []byte) ([]byte, error) {
+	return []byte(b.enc.EncodeToString(data)), nil
+}
+
+func (b *base32Encoding) Decode(data []byte) ([]byte, error) {
+	return b.enc.DecodeString(string(data))
+}
+
+// crockfordEncoding is Crockford's Base32: unpadded, case-insensitive on decode,
+// tolerant of hyphens and of I/L/O typed in place of 1/1/0.
+type crockfordEncoding struct {
+	base32Encoding
+}
+
+func newCrockfordEncoding() *crockfordEncoding {
+	enc := base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+	return &crockfordEncoding{base32Encoding{enc: enc}}
+}
+
+func (c *crockfordEncoding) DecodeStr(s string) (string, error) {
+	data, err := c.Decode([]byte(s))
+	return string(data), err
+}
+
+func (c *crockfordEncoding) Decode(data []byte) ([]byte, error) {
+	normalized := crockfordReplacer.Replace(strings.ToUpper(string(data)))
+	return c.enc.DecodeString(normalized)
+}
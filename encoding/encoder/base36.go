@@ -0,0 +1,37 @@
+package encoder
+
+import "strings"
+
+const base36AlphabetString = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// base36Encoding encodes with lowercase digits and decodes either case.
+// Leading zero bytes are preserved as leading '0' characters.
+type base36Encoding struct {
+	alphabet *Alphabet
+}
+
+func newBase36Encoding() *base36Encoding {
+	a := newAlphabet(base36AlphabetString)
+	for i, c := range []byte(strings.ToUpper(base36AlphabetString)) {
+		a.decodeMap[c] = byte(i)
+	}
+	return &base36Encoding{alphabet: a}
+}
+
+func (b *base36Encoding) EncodeStr(s string) (string, error) {
+	data, err := b.Encode([]byte(s))
+	return string(data), err
+}
+
+func (b *base36Encoding) DecodeStr(s string) (string, error) {
+	data, err := b.Decode([]byte(s))
+	return string(data), err
+}
+
+func (b *base36Encoding) Encode(data []byte) ([]byte, error) {
+	return encodeRadix(data, b.alphabet), nil
+}
+
+func (b *base36Encoding) Decode(data []byte) ([]byte, error) {
+	return decodeRadix(data, b.alphabet)
+}
@@ -5,26 +5,6 @@ import (
 	"math/big"
 )
 
-const (
-	alphabetString = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-)
-
-type Alphabet struct {
-	chars     string
-	decodeMap [256]byte
-}
-
-func newAlphabet(chars string) *Alphabet {
-	a := &Alphabet{chars: chars}
-	for i := range a.decodeMap {
-		a.decodeMap[i] = 0xFF
-	}
-	for i, c := range []byte(chars) {
-		a.decodeMap[c] = byte(i)
-	}
-	return a
-}
-
 type base58Encoding struct {
 	alphabet *Alphabet
 }
@@ -39,50 +19,101 @@ func (b *base58Encoding) DecodeStr(s string) (string, error) {
 	return string(data), err
 }
 
-func newBase58Encoding() *base58Encoding {
-	return &base58Encoding{alphabet: newAlphabet(alphabetString)}
+func newBase58Encoding(o options) *base58Encoding {
+	a := BitcoinAlphabet
+	if o.alphabet != nil {
+		a = o.alphabet
+	}
+	return &base58Encoding{alphabet: a}
 }
 
 func (b *base58Encoding) Encode(data []byte) ([]byte, error) {
-	num := new(big.Int).SetBytes(data)
-	mod := new(big.Int)
-	output := make([]byte, 0)
+	return encodeRadix(data, b.alphabet), nil
+}
 
-	for num.Sign() > 0 {
-		num.DivMod(num, big.NewInt(58), mod)
-		output = append(output, b.alphabet.chars[mod.Int64()])
-	}
+func (b *base58Encoding) Decode(data []byte) ([]byte, error) {
+	return decodeRadix(data, b.alphabet)
+}
 
-	for i := 0; i < len(data) && data[i] == 0; i++ {
-		output = append(output, b.alphabet.chars[0])
+// bigDigits are the digits math/big uses for bases up to 62. The radix
+// helpers convert through big.Int.Text and SetString, mapping each digit to
+// the alphabet: Text splits large numbers recursively and SetString consumes a
+// machine word of digits per step, unlike a per-digit DivMod loop.
+const bigDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// encodeRadix encodes data as a big-endian number in the base of the alphabet,
+// writing one leading zero symbol per leading zero byte so they survive a round trip.
+func encodeRadix(data []byte, a *Alphabet) []byte {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
 	}
 
-	return []byte(reverse(output)), nil
+	output := make([]byte, zeros)
+	for i := range output {
+		output[i] = a.chars[0]
+	}
+	return appendRadixDigits(output, new(big.Int).SetBytes(data), a)
 }
 
-func (b *base58Encoding) Decode(data []byte) ([]byte, error) {
-	result := big.NewInt(0)
-	for _, c := range data {
-		val := b.alphabet.decodeMap[c]
-		if val == 0xFF {
-			return nil, fmt.Errorf("invalid character: %q", c)
-		}
-		result.Mul(result, big.NewInt(58))
-		result.Add(result, big.NewInt(int64(val)))
+// decodeRadix reverses encodeRadix.
+func decodeRadix(data []byte, a *Alphabet) ([]byte, error) {
+	num, err := parseRadix(data, a)
+	if err != nil {
+		return nil, err
 	}
 
-	decoded := result.Bytes()
 	zeroCount := 0
-	for zeroCount < len(data) && data[zeroCount] == b.alphabet.chars[0] {
+	for zeroCount < len(data) && a.decodeMap[data[zeroCount]] == 0 {
 		zeroCount++
 	}
 
-	return append(make([]byte, zeroCount), decoded...), nil
+	return num.FillBytes(make([]byte, zeroCount+(num.BitLen()+7)/8)), nil
+}
+
+// appendRadixDigits appends the digits of num in the alphabet's base, with no
+// leading zero symbols; zero appends nothing.
+func appendRadixDigits(dst []byte, num *big.Int, a *Alphabet) []byte {
+	if num.Sign() == 0 {
+		return dst
+	}
+	start := len(dst)
+	dst = num.Append(dst, len(a.chars))
+	for i, c := range dst[start:] {
+		dst[start+i] = a.chars[bigDigitValue(c)]
+	}
+	return dst
+}
+
+// parseRadix reads data as a number written in the alphabet.
+func parseRadix(data []byte, a *Alphabet) (*big.Int, error) {
+	if len(data) == 0 {
+		return new(big.Int), nil
+	}
+	digits := make([]byte, len(data))
+	for i, c := range data {
+		val := a.decodeMap[c]
+		if val == 0xFF {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, c)
+		}
+		digits[i] = bigDigits[val]
+	}
+
+	num, ok := new(big.Int).SetString(string(digits), len(a.chars))
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, data)
+	}
+	return num, nil
 }
 
-func reverse(b []byte) string {
-	for i := 0; i < len(b)/2; i++ {
-		b[i], b[len(b)-1-i] = b[len(b)-1-i], b[i]
+// bigDigitValue returns the value of a digit produced by big.Int.Text.
+func bigDigitValue(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c >= 'a':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 36
 	}
-	return string(b)
 }
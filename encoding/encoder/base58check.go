@@ -0,0 +1,54 @@
+package encoder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// checksumLen is the number of double-SHA256 bytes appended by Base58Check.
+const checksumLen = 4
+
+var (
+	// ErrChecksum is returned when a Base58Check string fails checksum verification.
+	ErrChecksum = errors.New("base58check: checksum mismatch")
+	// ErrInvalidFormat is returned when a Base58Check string is too short to
+	// hold a version byte and checksum.
+	ErrInvalidFormat = errors.New("base58check: invalid format")
+)
+
+// CheckEncode encodes payload with a leading version byte and a trailing
+// 4-byte double-SHA256 checksum using the Bitcoin Base58 alphabet.
+func CheckEncode(version byte, payload []byte) string {
+	data := make([]byte, 0, 1+len(payload)+checksumLen)
+	data = append(data, version)
+	data = append(data, payload...)
+	data = append(data, checksum(data)...)
+	return string(encodeRadix(data, BitcoinAlphabet))
+}
+
+// CheckDecode reverses CheckEncode. It returns an error wrapping
+// ErrInvalidCharacter for characters outside the alphabet, ErrInvalidFormat
+// for input that is too short and ErrChecksum when verification fails.
+func CheckDecode(s string) (version byte, payload []byte, err error) {
+	data, err := decodeRadix([]byte(s), BitcoinAlphabet)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 1+checksumLen {
+		return 0, nil, ErrInvalidFormat
+	}
+
+	body, sum := data[:len(data)-checksumLen], data[len(data)-checksumLen:]
+	if !bytes.Equal(checksum(body), sum) {
+		return 0, nil, fmt.Errorf("%w: got %x, want %x", ErrChecksum, sum, checksum(body))
+	}
+	return body[0], body[1:], nil
+}
+
+func checksum(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:checksumLen]
+}
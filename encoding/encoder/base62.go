@@ -1,19 +1,29 @@
 package encoder
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
 )
 
-var (
-	base62Alphabet = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
-)
-
-type base62Encoding struct{}
+// base62Encoding preserves leading zero bytes as leading '0' characters, the
+// same way Base58 uses '1'. In fixed-width mode every input of fixedBytes bytes
+// encodes to exactly width characters, left-padded with '0'.
+type base62Encoding struct {
+	alphabet   *Alphabet
+	fixedBytes int
+	width      int
+}
 
-func newBase62Encoding() *base62Encoding {
-	return &base62Encoding{}
+func newBase62Encoding(o options) *base62Encoding {
+	e := &base62Encoding{alphabet: Base62Alphabet}
+	if o.alphabet != nil {
+		e.alphabet = o.alphabet
+	}
+	if o.fixedBytes > 0 {
+		e.fixedBytes = o.fixedBytes
+		e.width = radixWidth(o.fixedBytes, len(e.alphabet.chars))
+	}
+	return e
 }
 
 func (e *base62Encoding) EncodeStr(s string) (string, error) {
@@ -27,31 +37,47 @@ func (e *base62Encoding) DecodeStr(s string) (string, error) {
 }
 
 func (e *base62Encoding) Encode(data []byte) ([]byte, error) {
-	num := new(big.Int).SetBytes(data)
-	base := big.NewInt(62)
-	zero := big.NewInt(0)
+	if e.fixedBytes == 0 {
+		return encodeRadix(data, e.alphabet), nil
+	}
+	if len(data) != e.fixedBytes {
+		return nil, fmt.Errorf("base62: fixed-width input must be %d bytes, got %d", e.fixedBytes, len(data))
+	}
 
-	var encoded []byte
-	for num.Cmp(zero) > 0 {
-		mod := new(big.Int)
-		num.DivMod(num, base, mod)
-		encoded = append([]byte{base62Alphabet[mod.Int64()]}, encoded...)
+	digits := appendRadixDigits(nil, new(big.Int).SetBytes(data), e.alphabet)
+	output := make([]byte, e.width-len(digits), e.width)
+	for i := range output {
+		output[i] = e.alphabet.chars[0]
 	}
-	return encoded, nil
+	return append(output, digits...), nil
 }
 
 func (e *base62Encoding) Decode(input []byte) ([]byte, error) {
-	base := big.NewInt(62)
-	result := big.NewInt(0)
+	if e.fixedBytes == 0 {
+		return decodeRadix(input, e.alphabet)
+	}
+	if len(input) != e.width {
+		return nil, fmt.Errorf("base62: fixed-width input must be %d characters, got %d", e.width, len(input))
+	}
 
-	for _, c := range input {
-		index := bytes.IndexByte(base62Alphabet, c)
-		if index < 0 {
-			return nil, fmt.Errorf("invalid character: %c", c)
-		}
-		result.Mul(result, base)
-		result.Add(result, big.NewInt(int64(index)))
+	result, err := parseRadix(input, e.alphabet)
+	if err != nil {
+		return nil, err
 	}
+	if result.BitLen() > 8*e.fixedBytes {
+		return nil, fmt.Errorf("base62: value overflows %d bytes", e.fixedBytes)
+	}
+	return result.FillBytes(make([]byte, e.fixedBytes)), nil
+}
 
-	return result.Bytes(), nil
+// radixWidth returns the number of digits in base needed to represent any
+// value of n bytes.
+func radixWidth(n, base int) int {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(8*n))
+	b := big.NewInt(int64(base))
+	width := 0
+	for p := big.NewInt(1); p.Cmp(limit) < 0; p.Mul(p, b) {
+		width++
+	}
+	return width
 }
@@ -2,7 +2,21 @@ package encoder
 
 import "encoding/base64"
 
-type base64Encoding struct{}
+type base64Encoding struct {
+	enc     *base64.Encoding
+	lineLen int
+}
+
+func newBase64Encoding(o options) *base64Encoding {
+	enc := base64.StdEncoding
+	if o.urlSafe {
+		enc = base64.URLEncoding
+	}
+	if o.noPadding {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	return &base64Encoding{enc: enc, lineLen: o.lineLen}
+}
 
 func (b *base64Encoding) EncodeStr(s string) (string, error) {
 	data, err := b.Encode([]byte(s))
@@ -15,9 +29,21 @@ func (b *base64Encoding) DecodeStr(s string) (string, error) {
 }
 
 func (b *base64Encoding) Encode(data []byte) ([]byte, error) {
-	return []byte(base64.StdEncoding.EncodeToString(data)), nil
+	encoded := b.enc.AppendEncode(nil, data)
+	if b.lineLen <= 0 || len(encoded) <= b.lineLen {
+		return encoded, nil
+	}
+
+	wrapped := make([]byte, 0, len(encoded)+2*(len(encoded)/b.lineLen))
+	for len(encoded) > b.lineLen {
+		wrapped = append(wrapped, encoded[:b.lineLen]...)
+		wrapped = append(wrapped, '\r', '\n')
+		encoded = encoded[b.lineLen:]
+	}
+	return append(wrapped, encoded...), nil
 }
 
+// Decode ignores CR and LF characters, so wrapped input decodes directly.
 func (b *base64Encoding) Decode(data []byte) ([]byte, error) {
-	return base64.StdEncoding.DecodeString(string(data))
+	return b.enc.AppendDecode(nil, data)
 }
@@ -0,0 +1,99 @@
+package encoder
+
+import (
+	"encoding/ascii85"
+	"fmt"
+)
+
+const z85AlphabetString = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+// ascii85Encoding is Adobe Ascii85 without the <~ ~> delimiters.
+type ascii85Encoding struct{}
+
+func (a *ascii85Encoding) EncodeStr(s string) (string, error) {
+	data, err := a.Encode([]byte(s))
+	return string(data), err
+}
+
+func (a *ascii85Encoding) DecodeStr(s string) (string, error) {
+	data, err := a.Decode([]byte(s))
+	return string(data), err
+}
+
+func (a *ascii85Encoding) Encode(data []byte) ([]byte, error) {
+	dst := make([]byte, ascii85.MaxEncodedLen(len(data)))
+	n := ascii85.Encode(dst, data)
+	return dst[:n], nil
+}
+
+func (a *ascii85Encoding) Decode(data []byte) ([]byte, error) {
+	// Each 'z' expands to four zero bytes, the largest possible growth.
+	dst := make([]byte, 4*len(data))
+	n, _, err := ascii85.Decode(dst, data, true)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:n], nil
+}
+
+// z85Encoding is the ZeroMQ Z85 encoding (RFC 32/Z85). Input to Encode must be
+// a multiple of 4 bytes and input to Decode a multiple of 5 characters.
+type z85Encoding struct {
+	alphabet *Alphabet
+}
+
+func newZ85Encoding() *z85Encoding {
+	return &z85Encoding{alphabet: newAlphabet(z85AlphabetString)}
+}
+
+func (z *z85Encoding) EncodeStr(s string) (string, error) {
+	data, err := z.Encode([]byte(s))
+	return string(data), err
+}
+
+func (z *z85Encoding) DecodeStr(s string) (string, error) {
+	data, err := z.Decode([]byte(s))
+	return string(data), err
+}
+
+func (z *z85Encoding) Encode(data []byte) ([]byte, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("z85: input length %d is not a multiple of 4", len(data))
+	}
+
+	out := make([]byte, len(data)/4*5)
+	for i, o := 0, 0; i < len(data); i, o = i+4, o+5 {
+		value := uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3])
+		for j := 4; j >= 0; j-- {
+			out[o+j] = z.alphabet.chars[value%85]
+			value /= 85
+		}
+	}
+	return out, nil
+}
+
+func (z *z85Encoding) Decode(data []byte) ([]byte, error) {
+	if len(data)%5 != 0 {
+		return nil, fmt.Errorf("z85: input length %d is not a multiple of 5", len(data))
+	}
+
+	out := make([]byte, len(data)/5*4)
+	for i, o := 0, 0; i < len(data); i, o = i+5, o+4 {
+		var value uint64
+		for _, c := range data[i : i+5] {
+			digit := z.alphabet.decodeMap[c]
+			if digit == 0xFF {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, c)
+			}
+			value = value*85 + uint64(digit)
+		}
+		if value > 0xFFFFFFFF {
+			return nil, fmt.Errorf("z85: group %q overflows 32 bits", data[i:i+5])
+		}
+		out[o] = byte(value >> 24)
+		out[o+1] = byte(value >> 16)
+		out[o+2] = byte(value >> 8)
+		out[o+3] = byte(value)
+	}
+	return out, nil
+}
@@ -1,12 +1,21 @@
 package encoder
 
+import "encoding/base32"
+
 // BaseType defines supported base encoding formats.
 type BaseType int
 
 const (
-	Base58 BaseType = iota // Base58 encoding (commonly used in Bitcoin addresses)
-	Base62                 // Base62 encoding (compact, URL-safe)
-	Base64                 // Base64 encoding (standard in web/data transfer)
+	Base58      BaseType = iota // Base58 encoding (commonly used in Bitcoin addresses)
+	Base62                      // Base62 encoding (compact, URL-safe)
+	Base64                      // Base64 encoding (standard in web/data transfer)
+	Base32                      // Base32 encoding (RFC 4648 standard alphabet, padded)
+	Base32Hex                   // Base32hex encoding (RFC 4648 extended hex alphabet, padded)
+	Base36                      // Base36 encoding (lowercase, case-insensitive decoding)
+	Ascii85                     // Ascii85 encoding (Adobe variant, without delimiters)
+	Z85                         // Z85 encoding (ZeroMQ, input must be a multiple of 4 bytes)
+	Crockford32                 // Crockford Base32 (unpadded, forgiving decoding for human-typed codes)
+	Hex                         // Hexadecimal encoding (lowercase)
 )
 
 // Encoding defines a common interface for encoding and decoding operations.
@@ -19,15 +28,41 @@ type Encoding interface {
 }
 
 // NewEncoding returns an Encoding implementation based on the selected BaseType.
-// It supports Base58, Base62, and Base64 encodings. Returns nil if base is unsupported.
-func NewEncoding(base BaseType) Encoding {
+// It supports the Base58, Base62, Base64, Base32, Base32Hex, Base36, Ascii85, Z85,
+// Crockford32 and Hex encodings. Options such as WithURLSafe, WithNoPadding and
+// WithMIME select the Base64 variant; WithFixedWidth fixes the Base62 output
+// length; WithAlphabet swaps the Base58 or Base62 alphabet. Returns nil if base
+// is unsupported.
+func NewEncoding(base BaseType, opts ...Option) Encoding {
+	o := newOptions(opts)
+
 	switch base {
 	case Base58:
-		return newBase58Encoding()
+		if o.alphabet != nil && len(o.alphabet.chars) != 58 {
+			return nil
+		}
+		return newBase58Encoding(o)
 	case Base62:
-		return newBase62Encoding()
+		if o.alphabet != nil && len(o.alphabet.chars) != 62 {
+			return nil
+		}
+		return newBase62Encoding(o)
 	case Base64:
-		return &base64Encoding{}
+		return newBase64Encoding(o)
+	case Base32:
+		return newBase32Encoding(base32.StdEncoding)
+	case Base32Hex:
+		return newBase32Encoding(base32.HexEncoding)
+	case Base36:
+		return newBase36Encoding()
+	case Ascii85:
+		return &ascii85Encoding{}
+	case Z85:
+		return newZ85Encoding()
+	case Crockford32:
+		return newCrockfordEncoding()
+	case Hex:
+		return &hexEncoding{}
 	default:
 		return nil
 	}
@@ -1,7 +1,13 @@
 package encoder
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -111,3 +117,443 @@ func TestBase64Decode(t *testing.T) {
 		t.Fatalf("Decoded string does not match original: got %s, want %s", decoded, data)
 	}
 }
+
+func TestEncodingKnownVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		base BaseType
+		in   []byte
+		want string
+	}{
+		{"Base32", Base32, []byte("foobar"), "MZXW6YTBOI======"},
+		{"Base32Hex", Base32Hex, []byte("foobar"), "CPNMUOJ1E8======"},
+		{"Base36", Base36, []byte("hello world"), "fuvrsivvnfrbjwajo"},
+		{"Ascii85", Ascii85, []byte("hello"), "BOu!rDZ"},
+		{"Z85", Z85, []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}, "HelloWorld"},
+		{"Crockford32", Crockford32, []byte("foobar"), "CSQPYRK1E8"},
+		{"Hex", Hex, []byte("foobar"), "666f6f626172"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoding(tt.base)
+			encoded, err := enc.Encode(tt.in)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if string(encoded) != tt.want {
+				t.Fatalf("Expected %s, got %s", tt.want, encoded)
+			}
+
+			decoded, err := enc.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if string(decoded) != string(tt.in) {
+				t.Fatalf("Decoded data does not match original: got %x, want %x", decoded, tt.in)
+			}
+		})
+	}
+}
+
+func TestCrockfordDecodeForgiving(t *testing.T) {
+	enc := NewEncoding(Crockford32)
+
+	decoded, err := enc.DecodeStr("csqp-yrk1-e8")
+	if err != nil {
+		t.Fatalf("Error decoding string: %v", err)
+	}
+	if decoded != "foobar" {
+		t.Fatalf("Expected foobar, got %q", decoded)
+	}
+
+	strict, err := enc.DecodeStr("01")
+	if err != nil {
+		t.Fatalf("Error decoding string: %v", err)
+	}
+	loose, err := enc.DecodeStr("oI")
+	if err != nil {
+		t.Fatalf("Error decoding string: %v", err)
+	}
+	if strict != loose {
+		t.Fatalf("Expected O/I to decode as 0/1: got %x, want %x", loose, strict)
+	}
+}
+
+func TestBase36LeadingZeros(t *testing.T) {
+	enc := NewEncoding(Base36)
+
+	data := []byte{0, 0, 1, 2, 3}
+	encoded, err := enc.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	decoded, err := enc.Decode([]byte(strings.ToUpper(string(encoded))))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(decoded) != string(data) {
+		t.Fatalf("Decoded data does not match original: got %x, want %x", decoded, data)
+	}
+}
+
+func TestZ85InvalidLength(t *testing.T) {
+	enc := NewEncoding(Z85)
+
+	if _, err := enc.Encode([]byte("abc")); err == nil {
+		t.Fatal("Expected error for input not a multiple of 4")
+	}
+	if _, err := enc.Decode([]byte("abcd")); err == nil {
+		t.Fatal("Expected error for input not a multiple of 5")
+	}
+}
+
+func TestBase64Variants(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0xbf, 0x01}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"Standard", nil, "+/+/AQ=="},
+		{"URLSafe", []Option{WithURLSafe()}, "-_-_AQ=="},
+		{"Raw", []Option{WithNoPadding()}, "+/+/AQ"},
+		{"RawURL", []Option{WithURLSafe(), WithNoPadding()}, "-_-_AQ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoding(Base64, tt.opts...)
+			encoded, err := enc.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if string(encoded) != tt.want {
+				t.Fatalf("Expected %s, got %s", tt.want, encoded)
+			}
+
+			decoded, err := enc.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if string(decoded) != string(data) {
+				t.Fatalf("Decoded data does not match original: got %x, want %x", decoded, data)
+			}
+		})
+	}
+}
+
+func TestBase64MIME(t *testing.T) {
+	enc := NewEncoding(Base64, WithMIME())
+
+	data := []byte(strings.Repeat("mime payload ", 20))
+	encoded, err := enc.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	lines := strings.Split(string(encoded), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected wrapped output, got a single line of %d chars", len(encoded))
+	}
+	for i, line := range lines {
+		if len(line) > MIMELineLength {
+			t.Fatalf("Line %d has %d chars, want at most %d", i, len(line), MIMELineLength)
+		}
+	}
+
+	decoded, err := enc.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(decoded) != string(data) {
+		t.Fatalf("Decoded data does not match original")
+	}
+}
+
+func TestBase62LeadingZeros(t *testing.T) {
+	enc := NewEncoding(Base62)
+
+	data := []byte{0, 0, 1}
+	encoded, err := enc.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if string(encoded) != "001" {
+		t.Fatalf("Expected 001, got %s", encoded)
+	}
+
+	decoded, err := enc.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if string(decoded) != string(data) {
+		t.Fatalf("Decoded data does not match original: got %x, want %x", decoded, data)
+	}
+}
+
+func TestBase62FixedWidth(t *testing.T) {
+	enc := NewEncoding(Base62, WithFixedWidth(16))
+
+	ids := [][]byte{
+		make([]byte, 16),
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		[]byte("\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff\xff"),
+	}
+	for _, id := range ids {
+		encoded, err := enc.Encode(id)
+		if err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+		if len(encoded) != 22 {
+			t.Fatalf("Expected 22 characters, got %d (%s)", len(encoded), encoded)
+		}
+
+		decoded, err := enc.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if string(decoded) != string(id) {
+			t.Fatalf("Decoded data does not match original: got %x, want %x", decoded, id)
+		}
+	}
+
+	if _, err := enc.Encode([]byte("short")); err == nil {
+		t.Fatal("Expected error for wrong input length")
+	}
+	if _, err := enc.Decode([]byte(strings.Repeat("z", 22))); err == nil {
+		t.Fatal("Expected error for value overflowing 16 bytes")
+	}
+}
+
+func TestCustomAlphabets(t *testing.T) {
+	data := []byte("hello world")
+
+	tests := []struct {
+		name     string
+		base     BaseType
+		alphabet *Alphabet
+		want     string
+	}{
+		{"Bitcoin", Base58, BitcoinAlphabet, "StV1DL6CwTryKyV"},
+		{"Ripple", Base58, RippleAlphabet, "StVrDLaUATiyKyV"},
+		{"Flickr", Base58, FlickrAlphabet, "rTu1dk6cWsRYjYu"},
+		{"Base62Lower", Base62, Base62LowerAlphabet, "aaWF93RVY4AwqvW"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := NewEncoding(tt.base, WithAlphabet(tt.alphabet))
+			encoded, err := enc.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if string(encoded) != tt.want {
+				t.Fatalf("Expected %s, got %s", tt.want, encoded)
+			}
+
+			decoded, err := enc.Decode(encoded)
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if string(decoded) != string(data) {
+				t.Fatalf("Decoded data does not match original: got %s, want %s", decoded, data)
+			}
+		})
+	}
+}
+
+func TestNewAlphabetValidation(t *testing.T) {
+	if _, err := NewAlphabet(Base58, "abc"); !errors.Is(err, ErrAlphabetLength) {
+		t.Fatalf("Expected ErrAlphabetLength, got %v", err)
+	}
+
+	dup := "1" + BitcoinAlphabet.String()[1:57] + "1"
+	if _, err := NewAlphabet(Base58, dup); !errors.Is(err, ErrAlphabetDuplicate) {
+		t.Fatalf("Expected ErrAlphabetDuplicate, got %v", err)
+	}
+
+	if _, err := NewAlphabet(Base64, Base62Alphabet.String()); err == nil {
+		t.Fatal("Expected error for unsupported base")
+	}
+
+	if enc := NewEncoding(Base58, WithAlphabet(Base62Alphabet)); enc != nil {
+		t.Fatal("Expected nil encoding for mismatched alphabet")
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	// Bitcoin P2PKH address for the hash160 of the uncompressed generator point.
+	payload := []byte{
+		0x91, 0xb2, 0x4b, 0xf9, 0xf5, 0x28, 0x85, 0x32, 0x96, 0x0a,
+		0xc6, 0x87, 0xab, 0xb0, 0x35, 0x12, 0x7b, 0x1d, 0x28, 0xa5,
+	}
+	want := "1EHNa6Q4Jz2uvNExL497mE43ikXhwF6kZm"
+
+	encoded := CheckEncode(0x00, payload)
+	if encoded != want {
+		t.Fatalf("Expected %s, got %s", want, encoded)
+	}
+
+	version, decoded, err := CheckDecode(encoded)
+	if err != nil {
+		t.Fatalf("CheckDecode failed: %v", err)
+	}
+	if version != 0x00 || string(decoded) != string(payload) {
+		t.Fatalf("Unexpected result: version %d, payload %x", version, decoded)
+	}
+}
+
+func TestBase58CheckErrors(t *testing.T) {
+	encoded := CheckEncode(0x05, []byte("payload"))
+
+	tampered := []byte(encoded)
+	if tampered[len(tampered)-1] == 'a' {
+		tampered[len(tampered)-1] = 'b'
+	} else {
+		tampered[len(tampered)-1] = 'a'
+	}
+	if _, _, err := CheckDecode(string(tampered)); !errors.Is(err, ErrChecksum) {
+		t.Fatalf("Expected ErrChecksum, got %v", err)
+	}
+
+	if _, _, err := CheckDecode(encoded[:3] + "0" + encoded[4:]); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatalf("Expected ErrInvalidCharacter, got %v", err)
+	}
+
+	if _, _, err := CheckDecode("1"); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("Expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	data := []byte(strings.Repeat("streaming attachment data ", 200))
+
+	tests := []struct {
+		name string
+		enc  Encoding
+	}{
+		{"Base64", NewEncoding(Base64)},
+		{"Base64MIME", NewEncoding(Base64, WithMIME())},
+		{"Base64RawURL", NewEncoding(Base64, WithURLSafe(), WithNoPadding())},
+		{"Base32", NewEncoding(Base32)},
+		{"Base32Hex", NewEncoding(Base32Hex)},
+		{"Crockford32", NewEncoding(Crockford32)},
+		{"Hex", NewEncoding(Hex)},
+		{"Ascii85", NewEncoding(Ascii85)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewEncoder(tt.enc, &buf)
+			if err != nil {
+				t.Fatalf("NewEncoder failed: %v", err)
+			}
+			for chunk := range slices.Chunk(data, 7) {
+				if _, err := w.Write(chunk); err != nil {
+					t.Fatalf("Write failed: %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			want, err := tt.enc.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("Streamed output differs from Encode")
+			}
+
+			r, err := NewDecoder(tt.enc, &buf)
+			if err != nil {
+				t.Fatalf("NewDecoder failed: %v", err)
+			}
+			decoded, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll failed: %v", err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Fatalf("Decoded data does not match original")
+			}
+		})
+	}
+}
+
+func TestStreamCrockfordForgiving(t *testing.T) {
+	r, err := NewDecoder(NewEncoding(Crockford32), strings.NewReader("csqp-yrk1-e8"))
+	if err != nil {
+		t.Fatalf("NewDecoder failed: %v", err)
+	}
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if string(decoded) != "foobar" {
+		t.Fatalf("Expected foobar, got %q", decoded)
+	}
+}
+
+func TestStreamNotSupported(t *testing.T) {
+	for _, base := range []BaseType{Base58, Base62, Base36, Z85} {
+		if _, err := NewEncoder(NewEncoding(base), io.Discard); !errors.Is(err, ErrNotStreamable) {
+			t.Fatalf("Expected ErrNotStreamable for %d, got %v", base, err)
+		}
+		if _, err := NewDecoder(NewEncoding(base), strings.NewReader("")); !errors.Is(err, ErrNotStreamable) {
+			t.Fatalf("Expected ErrNotStreamable for %d, got %v", base, err)
+		}
+	}
+}
+
+func TestRadixRoundTripLarge(t *testing.T) {
+	data := make([]byte, 4096)
+	for i := range data {
+		data[i] = byte(i*31 + 7)
+	}
+	data[0], data[1] = 0, 0
+
+	encodings := map[string]Encoding{
+		"base58": NewEncoding(Base58),
+		"base62": NewEncoding(Base62),
+		"base36": NewEncoding(Base36),
+		"fixed":  NewEncoding(Base62, WithFixedWidth(len(data))),
+	}
+	for name, enc := range encodings {
+		encoded, err := enc.Encode(data)
+		if err != nil {
+			t.Fatalf("%s: Encode failed: %v", name, err)
+		}
+		decoded, err := enc.Decode(encoded)
+		if err != nil {
+			t.Fatalf("%s: Decode failed: %v", name, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("%s: round trip mismatch", name)
+		}
+	}
+}
+
+func BenchmarkBase62(b *testing.B) {
+	enc := NewEncoding(Base62)
+	for _, size := range []int{256, 1024, 4096, 16384} {
+		data := bytes.Repeat([]byte{0xa5}, size)
+		encoded, _ := enc.Encode(data)
+
+		b.Run(fmt.Sprintf("Encode/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for b.Loop() {
+				_, _ = enc.Encode(data)
+			}
+		})
+		b.Run(fmt.Sprintf("Decode/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for b.Loop() {
+				_, _ = enc.Decode(encoded)
+			}
+		})
+	}
+}
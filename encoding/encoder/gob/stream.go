@@ -0,0 +1,81 @@
+package gob
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encoder writes a stream of gob values to an io.Writer. Type information is
+// sent once per type rather than once per value, so encoding many values through
+// one Encoder is much smaller than calling EncodeGob for each.
+type Encoder struct {
+	enc *gob.Encoder
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{enc: gob.NewEncoder(w)}
+}
+
+// Encode writes the next value to the stream.
+func (e *Encoder) Encode(v any) error {
+	return e.enc.Encode(v)
+}
+
+// Decoder reads a stream of gob values written by an Encoder.
+type Decoder struct {
+	dec *gob.Decoder
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: gob.NewDecoder(r)}
+}
+
+// Decode reads the next value into v, which must be a pointer. It returns
+// io.EOF when the stream ends cleanly between values.
+func (d *Decoder) Decode(v any) error {
+	return d.dec.Decode(v)
+}
+
+// DecodeAll reads values of type T from r until the stream ends.
+func DecodeAll[T any](r io.Reader) ([]T, error) {
+	dec := NewDecoder(r)
+
+	var values []T
+	for {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+// Register records the concrete types of values so they can be encoded and
+// decoded through interface-typed fields or values. It is a bulk form of
+// gob.Register that returns an error instead of panicking when a name is
+// already registered for a different type.
+func Register(values ...any) error {
+	for _, v := range values {
+		if err := register(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func register(v any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("gob: register %T: %v", v, r)
+		}
+	}()
+	gob.Register(v)
+	return nil
+}
@@ -0,0 +1,88 @@
+package gob
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+type event interface {
+	Kind() string
+}
+
+type created struct {
+	ID   int
+	Name string
+}
+
+func (created) Kind() string { return "created" }
+
+type deleted struct {
+	ID int
+}
+
+func (deleted) Kind() string { return "deleted" }
+
+type logEntry struct {
+	Seq   int
+	Event event
+}
+
+func TestStream(t *testing.T) {
+	if err := Register(created{}, deleted{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	entries := []logEntry{
+		{1, created{1, "first"}},
+		{2, created{2, "second"}},
+		{3, deleted{1}},
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	perValue := 0
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			t.Error(err)
+			return
+		}
+		single, err := EncodeGob(e)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		perValue += len(single)
+	}
+
+	if buf.Len() >= perValue {
+		t.Errorf("stream size %d should be smaller than per-value size %d", buf.Len(), perValue)
+		return
+	}
+
+	got, err := DecodeAll[logEntry](&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("got %+v, want %+v", got, entries)
+		return
+	}
+}
+
+func TestRegisterConflict(t *testing.T) {
+	if err := Register(deleted{}, deleted{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// A different type whose name collides with an already registered one.
+	type deleted struct{ Reason string }
+	if err := Register(deleted{}); err == nil {
+		t.Error("expected error for conflicting registration")
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package encoder
+
+import "encoding/hex"
+
+type hexEncoding struct{}
+
+func (h *hexEncoding) EncodeStr(s string) (string, error) {
+	data, err := h.Encode([]byte(s))
+	return string(data), err
+}
+
+func (h *hexEncoding) DecodeStr(s string) (string, error) {
+	data, err := h.Decode([]byte(s))
+	return string(data), err
+}
+
+func (h *hexEncoding) Encode(data []byte) ([]byte, error) {
+	return []byte(hex.EncodeToString(data)), nil
+}
+
+func (h *hexEncoding) Decode(data []byte) ([]byte, error) {
+	return hex.DecodeString(string(data))
+}
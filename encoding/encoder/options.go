@@ -0,0 +1,67 @@
+package encoder
+
+// MIMELineLength is the maximum encoded line length mandated by RFC 2045.
+const MIMELineLength = 76
+
+// Option configures an Encoding returned by NewEncoding.
+type Option func(*options)
+
+type options struct {
+	urlSafe    bool
+	noPadding  bool
+	lineLen    int
+	fixedBytes int
+	alphabet   *Alphabet
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithURLSafe selects the URL and filename safe Base64 alphabet (RFC 4648 §5).
+func WithURLSafe() Option {
+	return func(o *options) {
+		o.urlSafe = true
+	}
+}
+
+// WithNoPadding omits the trailing '=' padding, as used by JWTs.
+func WithNoPadding() Option {
+	return func(o *options) {
+		o.noPadding = true
+	}
+}
+
+// WithLineLength wraps encoded output with CRLF every n characters. Decoding
+// ignores line breaks regardless of this setting.
+func WithLineLength(n int) Option {
+	return func(o *options) {
+		o.lineLen = n
+	}
+}
+
+// WithMIME wraps encoded output at 76 columns with CRLF line breaks (RFC 2045).
+func WithMIME() Option {
+	return WithLineLength(MIMELineLength)
+}
+
+// WithFixedWidth makes Base62 encode inputs of exactly n bytes to a constant
+// number of characters, left-padded with '0'. A 16-byte (128-bit) ID always
+// encodes to 22 characters.
+func WithFixedWidth(n int) Option {
+	return func(o *options) {
+		o.fixedBytes = n
+	}
+}
+
+// WithAlphabet replaces the Base58 or Base62 alphabet. NewEncoding returns nil
+// if the alphabet was built for a different base.
+func WithAlphabet(a *Alphabet) Option {
+	return func(o *options) {
+		o.alphabet = a
+	}
+}
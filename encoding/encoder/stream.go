@@ -0,0 +1,153 @@
+package encoder
+
+import (
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotStreamable is returned by NewEncoder and NewDecoder for encodings that
+// need the whole input at once, such as Base58, Base62 and Base36.
+var ErrNotStreamable = errors.New("encoder: encoding does not support streaming")
+
+// StreamEncoding is implemented by encodings that can encode and decode
+// incrementally: Base64, Base32, Base32Hex, Crockford32, Hex and Ascii85.
+type StreamEncoding interface {
+	Encoding
+	// NewEncoder returns a writer that encodes to w. Close flushes any
+	// partially written block and must be called when done.
+	NewEncoder(w io.Writer) io.WriteCloser
+	// NewDecoder returns a reader that decodes from r.
+	NewDecoder(r io.Reader) io.Reader
+}
+
+// NewEncoder returns a streaming encoder for e writing to w, or an error
+// wrapping ErrNotStreamable if e cannot be streamed.
+func NewEncoder(e Encoding, w io.Writer) (io.WriteCloser, error) {
+	s, ok := e.(StreamEncoding)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotStreamable, e)
+	}
+	return s.NewEncoder(w), nil
+}
+
+// NewDecoder returns a streaming decoder for e reading from r, or an error
+// wrapping ErrNotStreamable if e cannot be streamed.
+func NewDecoder(e Encoding, r io.Reader) (io.Reader, error) {
+	s, ok := e.(StreamEncoding)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotStreamable, e)
+	}
+	return s.NewDecoder(r), nil
+}
+
+func (b *base64Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	if b.lineLen > 0 {
+		w = &lineWriter{w: w, lineLen: b.lineLen}
+	}
+	return base64.NewEncoder(b.enc, w)
+}
+
+func (b *base64Encoding) NewDecoder(r io.Reader) io.Reader {
+	return base64.NewDecoder(b.enc, r)
+}
+
+func (b *base32Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	return base32.NewEncoder(b.enc, w)
+}
+
+func (b *base32Encoding) NewDecoder(r io.Reader) io.Reader {
+	return base32.NewDecoder(b.enc, r)
+}
+
+func (c *crockfordEncoding) NewDecoder(r io.Reader) io.Reader {
+	return base32.NewDecoder(c.enc, &crockfordReader{r: r})
+}
+
+func (h *hexEncoding) NewEncoder(w io.Writer) io.WriteCloser {
+	return nopWriteCloser{hex.NewEncoder(w)}
+}
+
+func (h *hexEncoding) NewDecoder(r io.Reader) io.Reader {
+	return hex.NewDecoder(r)
+}
+
+func (a *ascii85Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	return ascii85.NewEncoder(w)
+}
+
+func (a *ascii85Encoding) NewDecoder(r io.Reader) io.Reader {
+	return ascii85.NewDecoder(r)
+}
+
+// lineWriter inserts CRLF every lineLen bytes, matching the wrapping done by
+// base64Encoding.Encode.
+type lineWriter struct {
+	w       io.Writer
+	lineLen int
+	col     int
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if l.col == l.lineLen {
+			if _, err := l.w.Write([]byte("\r\n")); err != nil {
+				return written, err
+			}
+			l.col = 0
+		}
+
+		n := min(len(p), l.lineLen-l.col)
+		m, err := l.w.Write(p[:n])
+		written += m
+		l.col += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// crockfordReader applies the Crockford decoding normalisation to a stream.
+type crockfordReader struct {
+	r io.Reader
+}
+
+func (c *crockfordReader) Read(p []byte) (int, error) {
+	for {
+		n, err := c.r.Read(p)
+		out := 0
+		for _, b := range p[:n] {
+			switch {
+			case b == '-':
+				continue
+			case b >= 'a' && b <= 'z':
+				b -= 'a' - 'A'
+			}
+			switch b {
+			case 'I', 'L':
+				b = '1'
+			case 'O':
+				b = '0'
+			}
+			p[out] = b
+			out++
+		}
+		// Avoid returning 0, nil when a read contained only hyphens.
+		if out > 0 || err != nil {
+			return out, err
+		}
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/utils/v2/encoding/codec"
+	"github.com/inovacc/utils/v2/encoding/compression"
+)
+
+// Option configures how Decode rebuilds a pipeline from its header.
+type Option func(*decodeOptions)
+
+type decodeOptions struct {
+	key          []byte
+	compressOpts []compression.Option
+}
+
+// WithKey supplies the AES key for payloads that were encrypted.
+func WithKey(key []byte) Option {
+	return func(o *decodeOptions) {
+		o.key = key
+	}
+}
+
+// WithCompressionOptions passes options, such as compression.WithMaxSize, to
+// the decompression stage.
+func WithCompressionOptions(opts ...compression.Option) Option {
+	return func(o *decodeOptions) {
+		o.compressOpts = opts
+	}
+}
+
+// Parse rebuilds the pipeline described by the header at the start of data.
+func Parse(data []byte, opts ...Option) (*Pipeline, error) {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	header, _, err := splitHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p := New()
+	fields := strings.Split(strings.TrimSuffix(header, ":"), ";")[1:]
+	for i, field := range fields {
+		kind, name, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed stage %q", ErrInvalidHeader, field)
+		}
+
+		switch kind {
+		case "codec":
+			if i != 0 {
+				return nil, fmt.Errorf("%w: codec must be the first stage", ErrInvalidHeader)
+			}
+			p.Serialize(codec.Format(name))
+		case kindCompress:
+			p.Compress(compression.TypeStr(name), o.compressOpts...)
+		case kindEncrypt:
+			if name != cipherAESGCM {
+				return nil, fmt.Errorf("%w: unknown cipher %q", ErrInvalidHeader, name)
+			}
+			if o.key == nil {
+				return nil, ErrMissingKey
+			}
+			p.Encrypt(o.key)
+		case kindEncode:
+			base, ok := parseEncoding(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown encoding %q", ErrInvalidHeader, name)
+			}
+			p.Encode(base)
+		default:
+			return nil, fmt.Errorf("%w: unknown stage %q", ErrInvalidHeader, kind)
+		}
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p, nil
+}
+
+// Decode reverses data produced by any pipeline and stores the result in v,
+// reading the stages from the header.
+func Decode(data []byte, v any, opts ...Option) error {
+	p, err := Parse(data, opts...)
+	if err != nil {
+		return err
+	}
+	return p.Reverse(data, v)
+}
+
+// DecodeBytes reverses data produced by ApplyBytes, reading the stages from the header.
+func DecodeBytes(data []byte, opts ...Option) ([]byte, error) {
+	p, err := Parse(data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.ReverseBytes(data)
+}
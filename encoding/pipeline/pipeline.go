@@ -0,0 +1,207 @@
+// Package pipeline composes serialization, compression, encryption and text
+// encoding into a single reversible transform. Output starts with a short
+// ASCII header listing the stages, so a receiver can reverse it with Decode
+// without knowing how it was built.
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/utils/v2/encoding/codec"
+	"github.com/inovacc/utils/v2/encoding/compression"
+	"github.com/inovacc/utils/v2/encoding/encoder"
+)
+
+// headerMagic starts every pipeline header. The header is the magic followed by
+// ";kind=name" for each stage in application order and terminated by ':', for
+// example "UPL1;codec=gob;compress=zstd;encrypt=aes-gcm;encode=base64:".
+const headerMagic = "UPL1"
+
+var (
+	// ErrInvalidHeader is returned when data does not start with a valid pipeline header.
+	ErrInvalidHeader = errors.New("pipeline: invalid header")
+	// ErrNoSerializer is returned when Apply or Reverse is used without a Serialize stage.
+	ErrNoSerializer = errors.New("pipeline: no serialize stage")
+)
+
+// stage is one reversible step over bytes. The pipeline header is passed to
+// every stage so encryption can authenticate it.
+type stage interface {
+	kind() string
+	name() string
+	apply(data []byte, header string) ([]byte, error)
+	reverse(data []byte, header string) ([]byte, error)
+}
+
+// Pipeline is an ordered list of stages. Build one with New and the chaining
+// methods; the first configuration error is reported by Apply and Reverse.
+type Pipeline struct {
+	codec  codec.Codec
+	format codec.Format
+	stages []stage
+	err    error
+}
+
+// New returns an empty pipeline.
+func New() *Pipeline {
+	return &Pipeline{}
+}
+
+// Serialize sets the format used to turn values into bytes. It always runs
+// first, regardless of where it appears in the chain.
+func (p *Pipeline) Serialize(f codec.Format) *Pipeline {
+	c, err := codec.New(f)
+	if err != nil {
+		return p.fail(err)
+	}
+	p.codec, p.format = c, f
+	return p
+}
+
+// Compress appends a compression stage. Options such as compression.WithLevel
+// tune the encoder and compression.WithMaxSize bounds decompression; they do not
+// need to match on the receiving side.
+func (p *Pipeline) Compress(t compression.TypeStr, opts ...compression.Option) *Pipeline {
+	return p.add(&compressStage{c: compression.NewCompress(t, opts...)})
+}
+
+// Encrypt appends an AES-GCM stage. The key must be 16, 24 or 32 bytes.
+func (p *Pipeline) Encrypt(key []byte) *Pipeline {
+	s, err := newEncryptStage(key)
+	if err != nil {
+		return p.fail(err)
+	}
+	return p.add(s)
+}
+
+// Encode appends a text encoding stage.
+func (p *Pipeline) Encode(base encoder.BaseType) *Pipeline {
+	s, err := newEncodeStage(base)
+	if err != nil {
+		return p.fail(err)
+	}
+	return p.add(s)
+}
+
+func (p *Pipeline) add(s stage) *Pipeline {
+	p.stages = append(p.stages, s)
+	return p
+}
+
+func (p *Pipeline) fail(err error) *Pipeline {
+	if p.err == nil {
+		p.err = err
+	}
+	return p
+}
+
+// Apply serializes v and runs every stage in order, returning the header
+// followed by the transformed payload.
+func (p *Pipeline) Apply(v any) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.codec == nil {
+		return nil, ErrNoSerializer
+	}
+
+	data, err := p.codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return p.ApplyBytes(data)
+}
+
+// ApplyBytes runs every stage except serialization over data.
+func (p *Pipeline) ApplyBytes(data []byte) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	header := p.header()
+	var err error
+	for _, s := range p.stages {
+		if data, err = s.apply(data, header); err != nil {
+			return nil, fmt.Errorf("pipeline: %s %s: %w", s.kind(), s.name(), err)
+		}
+	}
+	return append([]byte(header), data...), nil
+}
+
+// Reverse undoes Apply and stores the result in v. The header in data must
+// list the same stages as p.
+func (p *Pipeline) Reverse(data []byte, v any) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.codec == nil {
+		return ErrNoSerializer
+	}
+
+	payload, err := p.ReverseBytes(data)
+	if err != nil {
+		return err
+	}
+	return p.codec.Unmarshal(payload, v)
+}
+
+// ReverseBytes undoes ApplyBytes.
+func (p *Pipeline) ReverseBytes(data []byte) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	header, payload, err := splitHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if header != p.header() {
+		return nil, fmt.Errorf("%w: got %q, want %q", ErrInvalidHeader, header, p.header())
+	}
+
+	for i := len(p.stages) - 1; i >= 0; i-- {
+		s := p.stages[i]
+		if payload, err = s.reverse(payload, header); err != nil {
+			return nil, fmt.Errorf("pipeline: %s %s: %w", s.kind(), s.name(), err)
+		}
+	}
+	return payload, nil
+}
+
+// Stages returns the header description of each stage in application order,
+// such as "codec=gob" or "compress=zstd".
+func (p *Pipeline) Stages() []string {
+	var stages []string
+	if p.codec != nil {
+		stages = append(stages, "codec="+string(p.format))
+	}
+	for _, s := range p.stages {
+		stages = append(stages, s.kind()+"="+s.name())
+	}
+	return stages
+}
+
+func (p *Pipeline) header() string {
+	var b strings.Builder
+	b.WriteString(headerMagic)
+	for _, s := range p.Stages() {
+		b.WriteByte(';')
+		b.WriteString(s)
+	}
+	b.WriteByte(':')
+	return b.String()
+}
+
+func splitHeader(data []byte) (string, []byte, error) {
+	if !bytes.HasPrefix(data, []byte(headerMagic)) {
+		return "", nil, ErrInvalidHeader
+	}
+	end := bytes.IndexByte(data, ':')
+	if end < 0 {
+		return "", nil, ErrInvalidHeader
+	}
+	return string(data[:end+1]), data[end+1:], nil
+}
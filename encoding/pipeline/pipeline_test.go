@@ -0,0 +1,162 @@
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/inovacc/utils/v2/encoding/codec"
+	"github.com/inovacc/utils/v2/encoding/compression"
+	"github.com/inovacc/utils/v2/encoding/encoder"
+)
+
+type message struct {
+	ID   int
+	Body string
+	Tags []string
+}
+
+var testKey = bytes.Repeat([]byte{0x42}, 32)
+
+func sampleMessage() message {
+	return message{ID: 7, Body: strings.Repeat("pipeline payload ", 50), Tags: []string{"a", "b"}}
+}
+
+func TestApplyReverse(t *testing.T) {
+	p := New().
+		Serialize(codec.FormatGob).
+		Compress(compression.TypeZstd).
+		Encrypt(testKey).
+		Encode(encoder.Base64)
+
+	in := sampleMessage()
+	out, err := p.Apply(in)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	wantHeader := "UPL1;codec=gob;compress=zstd;encrypt=aes-gcm;encode=base64:"
+	if !strings.HasPrefix(string(out), wantHeader) {
+		t.Fatalf("unexpected header in %q", out[:min(len(out), 80)])
+	}
+
+	var got message
+	if err := p.Reverse(out, &got); err != nil {
+		t.Fatalf("Reverse failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, in) {
+		t.Fatalf("got %+v, want %+v", got, in)
+	}
+}
+
+func TestDecodeFromHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Pipeline
+	}{
+		{"json", New().Serialize(codec.FormatJSON)},
+		{"msgpack+gzip+hex", New().Serialize(codec.FormatMsgPack).Compress(compression.TypeGzip).Encode(encoder.Hex)},
+		{"cbor+encrypt+base58", New().Serialize(codec.FormatCBOR).Encrypt(testKey).Encode(encoder.Base58)},
+		{"gob+snappy+encrypt", New().Serialize(codec.FormatGob).Compress(compression.TypeSnappy).Encrypt(testKey)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := sampleMessage()
+			out, err := tt.p.Apply(in)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+
+			var got message
+			if err := Decode(out, &got, WithKey(testKey)); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, in) {
+				t.Fatalf("got %+v, want %+v", got, in)
+			}
+		})
+	}
+}
+
+func TestApplyBytes(t *testing.T) {
+	p := New().Compress(compression.TypeLz4).Encode(encoder.Base32)
+
+	data := []byte(strings.Repeat("raw bytes ", 100))
+	out, err := p.ApplyBytes(data)
+	if err != nil {
+		t.Fatalf("ApplyBytes failed: %v", err)
+	}
+
+	got, err := DecodeBytes(out)
+	if err != nil {
+		t.Fatalf("DecodeBytes failed: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("round trip mismatch")
+	}
+
+	if _, err := p.Apply(data); !errors.Is(err, ErrNoSerializer) {
+		t.Fatalf("expected ErrNoSerializer, got %v", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	p := New().Serialize(codec.FormatJSON).Encrypt(testKey)
+	out, err := p.Apply(sampleMessage())
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	var got message
+	if err := Decode(out, &got); !errors.Is(err, ErrMissingKey) {
+		t.Fatalf("expected ErrMissingKey, got %v", err)
+	}
+
+	wrongKey := bytes.Repeat([]byte{0x24}, 32)
+	if err := Decode(out, &got, WithKey(wrongKey)); err == nil {
+		t.Fatal("expected error for wrong key")
+	}
+
+	if err := Decode([]byte("not a pipeline"), &got); !errors.Is(err, ErrInvalidHeader) {
+		t.Fatalf("expected ErrInvalidHeader, got %v", err)
+	}
+
+	if err := Decode([]byte("UPL1;rot13=x:data"), &got); !errors.Is(err, ErrInvalidHeader) {
+		t.Fatalf("expected ErrInvalidHeader for unknown stage, got %v", err)
+	}
+
+	other := New().Serialize(codec.FormatGob)
+	if err := other.Reverse(out, &got); !errors.Is(err, ErrInvalidHeader) {
+		t.Fatalf("expected ErrInvalidHeader for mismatched pipeline, got %v", err)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	if _, err := New().Serialize("yaml").Apply(1); !errors.Is(err, codec.ErrUnknownFormat) {
+		t.Fatalf("expected ErrUnknownFormat, got %v", err)
+	}
+
+	if _, err := New().Serialize(codec.FormatJSON).Encrypt([]byte("short")).Apply(1); err == nil {
+		t.Fatal("expected error for invalid key size")
+	}
+}
+
+func TestHeaderAuthenticated(t *testing.T) {
+	p := New().Serialize(codec.FormatJSON).Encrypt(testKey)
+	out, err := p.Apply(sampleMessage())
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if _, err := DecodeBytes(out, WithKey(testKey)); err != nil {
+		t.Fatalf("DecodeBytes failed: %v", err)
+	}
+
+	tampered := bytes.Replace(out, []byte("codec=json"), []byte("codec=cbor"), 1)
+	if _, err := DecodeBytes(tampered, WithKey(testKey)); err == nil {
+		t.Fatal("expected tampered header to fail decryption")
+	}
+}
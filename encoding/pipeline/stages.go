@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/inovacc/utils/v2/encoding/compression"
+	"github.com/inovacc/utils/v2/encoding/encoder"
+)
+
+const (
+	kindCompress = "compress"
+	kindEncrypt  = "encrypt"
+	kindEncode   = "encode"
+
+	cipherAESGCM = "aes-gcm"
+)
+
+// ErrMissingKey is returned by Decode when the header lists an encryption
+// stage but no key was supplied with WithKey.
+var ErrMissingKey = errors.New("pipeline: encrypted payload requires a key")
+
+type compressStage struct {
+	c *compression.Compress
+}
+
+func (s *compressStage) kind() string { return kindCompress }
+func (s *compressStage) name() string { return string(s.c.Type) }
+
+func (s *compressStage) apply(data []byte, _ string) ([]byte, error)   { return s.c.Compress(data) }
+func (s *compressStage) reverse(data []byte, _ string) ([]byte, error) { return s.c.Decompress(data) }
+
+// encryptStage seals data with AES-GCM, prefixing a random nonce. The pipeline
+// header is the additional authenticated data, so a tampered stage list fails
+// decryption.
+type encryptStage struct {
+	aead cipher.AEAD
+}
+
+func newEncryptStage(key []byte) (*encryptStage, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %w", err)
+	}
+	return &encryptStage{aead: aead}, nil
+}
+
+func (s *encryptStage) kind() string { return kindEncrypt }
+func (s *encryptStage) name() string { return cipherAESGCM }
+
+func (s *encryptStage) apply(data []byte, header string) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(data)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, data, []byte(header)), nil
+}
+
+func (s *encryptStage) reverse(data []byte, header string) ([]byte, error) {
+	if len(data) < s.aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	return s.aead.Open(nil, nonce, ciphertext, []byte(header))
+}
+
+// encodingNames maps text encodings to their header names.
+var encodingNames = map[encoder.BaseType]string{
+	encoder.Base58:      "base58",
+	encoder.Base62:      "base62",
+	encoder.Base64:      "base64",
+	encoder.Base32:      "base32",
+	encoder.Base32Hex:   "base32hex",
+	encoder.Base36:      "base36",
+	encoder.Ascii85:     "ascii85",
+	encoder.Z85:         "z85",
+	encoder.Crockford32: "crockford32",
+	encoder.Hex:         "hex",
+}
+
+type encodeStage struct {
+	base encoder.BaseType
+	enc  encoder.Encoding
+}
+
+func newEncodeStage(base encoder.BaseType) (*encodeStage, error) {
+	if _, ok := encodingNames[base]; !ok {
+		return nil, fmt.Errorf("pipeline: unsupported encoding %d", base)
+	}
+	if base == encoder.Z85 {
+		// Z85 only accepts multiples of 4 bytes, which arbitrary payloads are not.
+		return nil, errors.New("pipeline: z85 cannot encode arbitrary-length payloads")
+	}
+	return &encodeStage{base: base, enc: encoder.NewEncoding(base)}, nil
+}
+
+func (s *encodeStage) kind() string { return kindEncode }
+func (s *encodeStage) name() string { return encodingNames[s.base] }
+
+func (s *encodeStage) apply(data []byte, _ string) ([]byte, error)   { return s.enc.Encode(data) }
+func (s *encodeStage) reverse(data []byte, _ string) ([]byte, error) { return s.enc.Decode(data) }
+
+func parseEncoding(name string) (encoder.BaseType, bool) {
+	for base, n := range encodingNames {
+		if n == name {
+			return base, true
+		}
+	}
+	return 0, false
+}